@@ -14,6 +14,7 @@ function shouldRenderTimer(state: RoomState) {
 		case RoomState.EnterPlayerInfo:
 		case RoomState.Unanimous:
 		case RoomState.Waiting:
+		case RoomState.TelephoneReveal:
 			return false;
 		default:
 			return true;
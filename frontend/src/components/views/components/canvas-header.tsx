@@ -1,4 +1,4 @@
-import { getDrawingPlayer } from "@/lib/player";
+import { getDrawingPlayers, joinNames } from "@/lib/player";
 import { RootState } from "@/state/store";
 import { useSelector } from "react-redux";
 import { motion } from "motion/react";
@@ -23,14 +23,22 @@ export function DrawingStatus({ delay }: { delay: number }) {
 	);
 	const players = useSelector((state: RootState) => state.room.players);
 	const playerId = useSelector((state: RootState) => state.room.playerId);
-	const drawingPlayer = getDrawingPlayer(players);
+	const relayTurn = useSelector((state: RootState) => state.game.relayTurn);
+	const drawingPlayers = getDrawingPlayers(players);
 
-	const isDrawing = drawingPlayer?.id === playerId;
+	const isDrawing = drawingPlayers.some((p) => p.id === playerId);
 
 	if (isDrawing) {
+		// Relay drawers wait for their turn with the word in view
+		const turnPlayer = relayTurn && players[relayTurn.playerId];
+		const label =
+			!turnPlayer || turnPlayer.id === playerId
+				? "You're sketching:"
+				: `${turnPlayer.username} is sketching your word:`;
+
 		return (
 			<span className="flex items-center text-sm gap-1 lg:text-2xl">
-				You're sketching:{" "}
+				{label}{" "}
 				<span className="lg:text-2xl font-bold">{selectedWord?.value}</span>
 			</span>
 		);
@@ -41,7 +49,8 @@ export function DrawingStatus({ delay }: { delay: number }) {
 	return (
 		<span className="flex items-start flex-wrap text-sm lg:text-2xl gap-1">
 			<span className="flex-wrap">
-				{drawingPlayer?.username} is sketching:{" "}
+				{joinNames(drawingPlayers.map((p) => p.username))}{" "}
+				{drawingPlayers.length > 1 ? "are" : "is"} sketching:{" "}
 			</span>
 			{containsLetterBlanks ? (
 				<span className=" lg:text-2xl font-bold">
@@ -22,7 +22,7 @@ import {
 
 import { undoStroke, clearStrokes } from "@/state/features/canvas";
 import { RaisedButton } from "../../ui/raised-button";
-import { getGameRole } from "@/lib/player";
+import { getCanvasRole } from "@/lib/player";
 import { GameRole } from "@/state/features/game";
 import { cn, hslToRgb } from "@/lib/utils";
 import { useState, useEffect, useMemo } from "react";
@@ -38,7 +38,8 @@ export function CanvasTools() {
 	const dispatch = useDispatch();
 	const players = useSelector((state: RootState) => state.room.players);
 	const playerId = useSelector((state: RootState) => state.room.playerId);
-	const role = getGameRole(playerId, players);
+	const relayTurn = useSelector((state: RootState) => state.game.relayTurn);
+	const role = getCanvasRole(playerId, players, relayTurn);
 
 	const [colorPaletteOpen, setColorPaletteOpen] = useState(false);
 
@@ -423,8 +424,9 @@ export function ColorSliders() {
 	);
 	const players = useSelector((state: RootState) => state.room.players);
 	const playerId = useSelector((state: RootState) => state.room.playerId);
+	const relayTurn = useSelector((state: RootState) => state.game.relayTurn);
 
-	const role = getGameRole(playerId, players);
+	const role = getCanvasRole(playerId, players, relayTurn);
 	const isDrawing = role === GameRole.Drawing;
 
 	const dispatch = useDispatch();
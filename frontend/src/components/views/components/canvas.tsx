@@ -4,7 +4,7 @@ import { RootState } from "@/state/store";
 import { GameRole } from "@/state/features/game";
 import { addStroke, addStrokePoint, Stroke } from "@/state/features/canvas";
 import { useWindowSize } from "@/hooks/use-window-size";
-import { getGameRole } from "@/lib/player";
+import { getCanvasRole } from "@/lib/player";
 import { addRecentlyUsedColor } from "@/state/features/client";
 import { useMediaQuery } from "@/hooks/use-media-query";
 import { CanvasTool } from "@/state/features/client";
@@ -42,7 +42,8 @@ function Canvas({
 
 	const players = useSelector((state: RootState) => state.room.players);
 	const playerId = useSelector((state: RootState) => state.room.playerId);
-	const role = getGameRole(playerId, players);
+	const relayTurn = useSelector((state: RootState) => state.game.relayTurn);
+	const role = getCanvasRole(playerId, players, relayTurn);
 
 	const isLargeScreen = useMediaQuery("(min-width: 1024px)");
 
@@ -403,6 +404,9 @@ function Canvas({
 	};
 
 	const handleTouchMove = (e: React.TouchEvent<HTMLCanvasElement>) => {
+		// Fills don't have any points after the first one
+		if (currentTool === CanvasTool.Bucket) return;
+
 		if (role === GameRole.Drawing && roundIsActive()) {
 			e.preventDefault();
 			const rect = e.currentTarget.getBoundingClientRect();
@@ -578,6 +582,7 @@ function Canvas({
 								color: strokeColor,
 								width: strokeWidth,
 								points: [[x, y]],
+								type: currentTool === CanvasTool.Eraser ? "eraser" : "brush",
 							})
 						);
 					}
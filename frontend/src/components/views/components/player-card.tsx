@@ -1,6 +1,11 @@
 import { motion } from "motion/react";
 import { AvatarConfig, generateAvatar } from "@/lib/avatar";
-import { Player, updatePlayerProfile } from "@/state/features/room";
+import {
+	GameMode,
+	Player,
+	RoomRole,
+	updatePlayerProfile,
+} from "@/state/features/room";
 import { forwardRef, useState } from "react";
 import { useSelector, useDispatch } from "react-redux";
 import { RootState } from "@/state/store";
@@ -24,6 +29,8 @@ import { RaisedButton } from "@/components/ui/raised-button";
 import { containerSpring } from "@/config/spring";
 import { SoundEffect, useSound } from "@/providers/sound-provider";
 import { changeAvatarConfig, changeUsername } from "@/state/features/client";
+import { teamColors, teamNames, teams } from "@/lib/team";
+import { cn } from "@/lib/utils";
 
 function CardContent({
 	player,
@@ -35,6 +42,15 @@ function CardContent({
 	return (
 		<div className="w-full justify-start bg-background h-12 rounded-lg shadow-accent-md">
 			<div className="flex items-center h-12 lg:w-64 min-h-0 flex-1 relative">
+				{player.team && (
+					<span
+						title={teamNames[player.team]}
+						className={cn(
+							"absolute right-2 size-3 rounded-full",
+							teamColors[player.team]
+						)}
+					/>
+				)}
 				<img
 					alt="Player avatar"
 					className="rounded-l-lg h-full aspect-square relative"
@@ -58,11 +74,39 @@ export const PlayerCard = forwardRef<HTMLDivElement, { player: Player }>(
 		const dispatch = useDispatch();
 		const playerId = useSelector((state: RootState) => state.room.playerId);
 		const isCurrentPlayer = playerId === player.id;
+		const isHost = useSelector(
+			(state: RootState) =>
+				state.room.players[state.room.playerId]?.roomRole === RoomRole.Host
+		);
+		const isTeamGame = useSelector(
+			(state: RootState) => state.room.settings.gameMode === GameMode.Teams
+		);
 		const [isEditPlayerOptionsOpen, setIsEditPlayerOptionsOpen] =
 			useState(false);
 
 		const playSound = useSound();
 
+		// Only the host can move players between teams
+		const teamItems =
+			isHost && isTeamGame && player.roomRole !== RoomRole.Spectator
+				? teams
+						.filter((team) => team !== player.team)
+						.map((team) => (
+							<DropdownMenuItem
+								key={team}
+								onSelect={() => {
+									playSound(SoundEffect.CLICK);
+									dispatch({
+										type: "room/assignTeam",
+										payload: { playerId: player.id, team },
+									});
+								}}
+							>
+								Move to {teamNames[team].toLowerCase()}
+							</DropdownMenuItem>
+						))
+				: null;
+
 		const handleSubmit = (profile: {
 			username: string;
 			avatarConfig: AvatarConfig;
@@ -109,6 +153,84 @@ export const PlayerCard = forwardRef<HTMLDivElement, { player: Player }>(
 									>
 										Edit profile
 									</DropdownMenuItem>
+									{teamItems}
+								</DropdownMenuGroup>
+							</DropdownMenuContent>
+						</DropdownMenu>
+					) : isHost ? (
+						<DropdownMenu modal={false}>
+							<DropdownMenuTrigger className="w-full flex">
+								<CardContent
+									player={player}
+									isCurrentPlayer={isCurrentPlayer}
+								/>
+							</DropdownMenuTrigger>
+							<DropdownMenuContent className="w-60 translate-x-1">
+								<DropdownMenuLabel>
+									<p className="font-bold">{player.username}</p>
+								</DropdownMenuLabel>
+								<DropdownMenuSeparator />
+								<DropdownMenuGroup>
+									<DropdownMenuItem
+										onSelect={() => {
+											playSound(SoundEffect.CLICK);
+											dispatch({ type: "room/mutePlayer", payload: player.id });
+										}}
+									>
+										{player.muted ? "Unmute" : "Mute"}
+									</DropdownMenuItem>
+									<DropdownMenuItem
+										onSelect={() => {
+											playSound(SoundEffect.CLICK);
+											dispatch({ type: "room/kickPlayer", payload: player.id });
+										}}
+									>
+										Kick
+									</DropdownMenuItem>
+									<DropdownMenuItem
+										onSelect={() => {
+											playSound(SoundEffect.CLICK);
+											dispatch({ type: "room/banPlayer", payload: player.id });
+										}}
+									>
+										Ban
+									</DropdownMenuItem>
+									{player.roomRole !== RoomRole.Spectator && (
+										<DropdownMenuItem
+											onSelect={() => {
+												playSound(SoundEffect.CLICK);
+												dispatch({ type: "room/transferHost", payload: player.id });
+											}}
+										>
+											Make host
+										</DropdownMenuItem>
+									)}
+									{teamItems}
+								</DropdownMenuGroup>
+							</DropdownMenuContent>
+						</DropdownMenu>
+					) : player.roomRole !== RoomRole.Spectator ? (
+						<DropdownMenu modal={false}>
+							<DropdownMenuTrigger className="w-full flex">
+								<CardContent
+									player={player}
+									isCurrentPlayer={isCurrentPlayer}
+								/>
+							</DropdownMenuTrigger>
+							<DropdownMenuContent className="w-60 translate-x-1">
+								<DropdownMenuLabel>
+									<p className="font-bold">{player.username}</p>
+								</DropdownMenuLabel>
+								<DropdownMenuSeparator />
+								<DropdownMenuGroup>
+									<DropdownMenuItem
+										onSelect={() => {
+											playSound(SoundEffect.CLICK);
+											dispatch({ type: "room/startVoteKick", payload: player.id });
+										}}
+									>
+										Vote to kick
+									</DropdownMenuItem>
 								</DropdownMenuGroup>
 							</DropdownMenuContent>
 						</DropdownMenu>
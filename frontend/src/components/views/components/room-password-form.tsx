@@ -0,0 +1,60 @@
+import { useState } from "react";
+import { useDispatch, useSelector } from "react-redux";
+import { LockIcon } from "lucide-react";
+import { Input } from "@/components/ui/input";
+import { Button } from "@/components/ui/button";
+import { changeRoomSettings } from "@/state/features/room";
+import { RootState } from "@/state/store";
+
+// The password is only sent when the host sets or removes it,
+// the server never sends it back so we can't show the current one.
+export function RoomPasswordForm() {
+	const dispatch = useDispatch();
+	const settings = useSelector((state: RootState) => state.room.settings);
+	const [password, setPassword] = useState("");
+
+	function handleSubmit(e: React.FormEvent) {
+		e.preventDefault();
+		if (!password) return;
+		dispatch(changeRoomSettings({ ...settings, password }));
+		setPassword("");
+	}
+
+	function handleRemove() {
+		dispatch(changeRoomSettings({ ...settings, password: "" }));
+	}
+
+	return (
+		<form onSubmit={handleSubmit} className="flex flex-col gap-2 w-full px-1">
+			<label className="flex items-center gap-1 text-sm font-medium">
+				<LockIcon className="size-4" />
+				Password
+			</label>
+			<div className="flex items-center gap-2">
+				<Input
+					type="password"
+					autoComplete="new-password"
+					maxLength={64}
+					value={password}
+					onChange={(e) => setPassword(e.target.value)}
+					placeholder={
+						settings.hasPassword ? "Enter a new password" : "No password"
+					}
+				/>
+				<Button type="submit" disabled={!password}>
+					Set
+				</Button>
+				{settings.hasPassword && (
+					<Button type="button" variant="outline" onClick={handleRemove}>
+						Remove
+					</Button>
+				)}
+			</div>
+			<p className="text-sm text-muted-foreground">
+				{settings.hasPassword
+					? "Players need the password to join"
+					: "Anyone with the code can join"}
+			</p>
+		</form>
+	);
+}
@@ -16,6 +16,7 @@ import { useSelector, useDispatch } from "react-redux";
 import {
 	changeRoomSettings,
 	GameMode,
+	ScoringMode,
 	WordBank,
 	WordDifficulty,
 } from "@/state/features/room";
@@ -28,11 +29,14 @@ import {
 	SelectValue,
 } from "@/components/ui/select";
 import { Textarea } from "@/components/ui/textarea";
+import { Switch } from "@/components/ui/switch";
 import {
 	ClockIcon,
+	GlobeIcon,
 	JoystickIcon,
 	SwordsIcon,
 	Tally5Icon,
+	TrophyIcon,
 	UsersIcon,
 	WholeWordIcon,
 } from "lucide-react";
@@ -45,7 +49,9 @@ const RoomFormSchema = z.object({
 	wordDifficulty: z.nativeEnum(WordDifficulty),
 	wordBank: z.nativeEnum(WordBank),
 	gameMode: z.nativeEnum(GameMode),
+	scoringMode: z.nativeEnum(ScoringMode),
 	customWords: z.string(),
+	public: z.boolean(),
 });
 
 export function RoomSettingsForm() {
@@ -57,7 +63,9 @@ export function RoomSettingsForm() {
 		wordDifficulty,
 		wordBank,
 		gameMode,
+		scoringMode,
 		customWords,
+		public: isPublic,
 	} = useSelector((state: RootState) => state.room.settings);
 
 	const [isEditing, setIsEditing] = useState(false);
@@ -75,6 +83,8 @@ export function RoomSettingsForm() {
 			wordDifficulty,
 			wordBank,
 			gameMode,
+			scoringMode,
+			public: isPublic,
 			customWords:
 				customWords.length > 0
 					? customWords.map((word) => ({ value: word })).join(",")
@@ -211,6 +221,32 @@ export function RoomSettingsForm() {
 					</div>
 
 					<div className="flex flex-col gap-4 w-full">
+						<FormField
+							control={form.control}
+							name="public"
+							render={({ field }) => (
+								<FormItem>
+									<FormLabel className="flex items-center gap-1">
+										<GlobeIcon className="size-4" />
+										Public Room
+									</FormLabel>
+									<FormControl>
+										<Switch
+											checked={field.value}
+											onCheckedChange={(checked: boolean) => {
+												field.onChange(checked);
+												handleChange();
+											}}
+										/>
+									</FormControl>
+									<FormDescription>
+										{field.value
+											? "Anyone can find and join this room"
+											: "Only players with the code can join"}
+									</FormDescription>
+								</FormItem>
+							)}
+						/>
 						<FormField
 							control={form.control}
 							name="gameMode"
@@ -237,9 +273,55 @@ export function RoomSettingsForm() {
 												<SelectItem value={GameMode.NoHints}>
 													No Hints
 												</SelectItem>
+												<SelectItem value={GameMode.Teams}>
+													Teams
+												</SelectItem>
+												<SelectItem value={GameMode.Telephone}>
+													Telephone
+												</SelectItem>
+												<SelectItem value={GameMode.Relay}>
+													Relay
+												</SelectItem>
+											</SelectContent>
+										</Select>
+									</FormControl>
+								</FormItem>
+							)}
+						/>
+						<FormField
+							control={form.control}
+							name="scoringMode"
+							render={({ field }) => (
+								<FormItem>
+									<FormLabel className="flex items-center gap-1">
+										<TrophyIcon className="size-4" />
+										Scoring
+									</FormLabel>
+									<FormControl>
+										<Select
+											defaultValue={field.value}
+											onValueChange={(val: string) =>
+												field.onChange(val as ScoringMode)
+											}
+										>
+											<SelectTrigger>
+												<SelectValue placeholder="Select a scoring mode" />
+											</SelectTrigger>
+											<SelectContent>
+												<SelectItem value={ScoringMode.Classic}>
+													Classic
+												</SelectItem>
+												<SelectItem value={ScoringMode.Timed}>
+													Timed
+												</SelectItem>
 											</SelectContent>
 										</Select>
 									</FormControl>
+									<FormDescription>
+										{field.value === ScoringMode.Timed
+											? "Faster guesses earn more points"
+											: "Earlier guessers earn more points"}
+									</FormDescription>
 								</FormItem>
 							)}
 						/>
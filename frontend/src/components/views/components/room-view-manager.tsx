@@ -14,13 +14,16 @@ import {
 	DrawingView,
 	PostDrawingView,
 	PickingView,
+	GameOverView,
+	TelephoneView,
+	TelephoneRevealView,
 } from "@/components/views";
 import { RootState } from "@/state/store";
 import { useSelector } from "react-redux";
 import { RoomState } from "@/state/features/room";
 import { AirplaneDoodle } from "@/components/doodle/airplane-doodle";
 import { RainCloudDoodle } from "@/components/doodle/rain-cloud-doodle";
-import { SkyScene } from "@/components/scenes/sky-scene";
+import { VoteKickBanner } from "./vote-kick-banner";
 
 export enum Direction {
 	UP,
@@ -317,11 +320,7 @@ const views: Record<RoomState, View> = {
 		],
 	},
 	[RoomState.GameOver]: {
-		Component: () => (
-			<SkyScene>
-				<div className="text-white text-2xl font-bold">Game over</div>
-			</SkyScene>
-		),
+		Component: GameOverView,
 		key: "game-over-view",
 		sprites: [
 			{
@@ -336,6 +335,51 @@ const views: Record<RoomState, View> = {
 			},
 		],
 	},
+	[RoomState.Telephone]: {
+		Component: TelephoneView,
+		key: "telephone-view",
+		sprites: [
+			{
+				style: {
+					left: 0.85,
+					top: 0.55,
+					rotate: 30,
+					opacity: 1,
+				},
+				Component: AirplaneDoodle,
+				key: "airplane-doodle",
+				type: SpriteType.STATIC,
+			},
+			{
+				Component: (props: HTMLMotionProps<"img">) => (
+					<RainCloudDoodle
+						duration={5}
+						className="top-[20%] right-[4%] hidden lg:block"
+						{...props}
+					/>
+				),
+				key: "rain-cloud-1",
+				type: SpriteType.BOBBING,
+			},
+		],
+	},
+	[RoomState.TelephoneReveal]: {
+		Component: TelephoneRevealView,
+		key: "telephone-reveal-view",
+		sprites: [
+			{
+				style: {
+					left: 0.15,
+					top: 0.55,
+					rotate: 25,
+					opacity: 1,
+				},
+				Component: AirplaneDoodle,
+				key: "airplane-doodle",
+				type: SpriteType.STATIC,
+			},
+		],
+	},
 	[RoomState.Unanimous]: {
 		Component: () => <></>,
 		key: "unanimous-view",
@@ -437,6 +481,7 @@ export function RoomViewManager() {
 					/>
 				</ViewTransitionContainer>
 			</AnimatePresence>
+			<VoteKickBanner />
 		</div>
 	);
 }
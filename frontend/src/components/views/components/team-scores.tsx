@@ -0,0 +1,38 @@
+import { useSelector } from "react-redux";
+import { RootState } from "@/state/store";
+import { AnimatedNumber } from "@/components/ui/animated-number";
+import { getTeamScores, teamColors, teamNames, teams } from "@/lib/team";
+import { cn } from "@/lib/utils";
+
+// Shows each team's score along with the points they got this round
+export function TeamScores() {
+	const players = useSelector((state: RootState) => state.room.players);
+	const teamPointsAwarded = useSelector(
+		(state: RootState) => state.game.teamPointsAwarded
+	);
+	const scores = getTeamScores(players);
+
+	return (
+		<div className="grid grid-cols-2 gap-4 max-w-xl w-full">
+			{teams.map((team) => {
+				const points = teamPointsAwarded?.[team] ?? 0;
+				return (
+					<div
+						key={team}
+						className={cn(
+							"flex items-center justify-between rounded-lg px-4 py-2 text-white shadow-accent-md",
+							teamColors[team]
+						)}
+					>
+						<p className="text-lg font-bold">{teamNames[team]}</p>
+						<p className="text-lg font-medium flex items-center gap-2">
+							{points > 0 && <span className="text-sm">+{points}</span>}
+							<AnimatedNumber value={scores[team]} previous={scores[team] - points} />{" "}
+							pts
+						</p>
+					</div>
+				);
+			})}
+		</div>
+	);
+}
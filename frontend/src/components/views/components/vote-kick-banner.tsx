@@ -0,0 +1,41 @@
+import { useDispatch, useSelector } from "react-redux";
+import { RootState } from "@/state/store";
+import { RaisedButton } from "@/components/ui/raised-button";
+import { RoomRole } from "@/state/features/room";
+
+// Shows the open vote kick to everyone in the room and lets players vote
+export function VoteKickBanner() {
+	const dispatch = useDispatch();
+	const voteKick = useSelector((state: RootState) => state.room.voteKick);
+	const players = useSelector((state: RootState) => state.room.players);
+	const playerId = useSelector((state: RootState) => state.room.playerId);
+
+	if (!voteKick) return null;
+
+	const target = players[voteKick.targetId];
+	const hasVoted = voteKick.votes.includes(playerId);
+	const canVote =
+		playerId !== voteKick.targetId &&
+		players[playerId]?.roomRole !== RoomRole.Spectator;
+
+	return (
+		<div className="fixed top-4 left-1/2 -translate-x-1/2 z-[100] bg-background rounded-lg shadow-accent-md p-3 flex items-center gap-3">
+			<p className="font-bold">
+				Kick {target?.username ?? "player"}? ({voteKick.votes.length}/
+				{voteKick.needed})
+			</p>
+			{canVote && (
+				<RaisedButton
+					size="sm"
+					variant="action"
+					shift={false}
+					onClick={() =>
+						dispatch({ type: "room/voteKick", payload: !hasVoted })
+					}
+				>
+					{hasVoted ? "Undo vote" : "Vote yes"}
+				</RaisedButton>
+			)}
+		</div>
+	);
+}
@@ -6,11 +6,12 @@ import { AnimatePresence } from "motion/react";
 import { CanvasHeader } from "./components/canvas-header";
 import { AnimatedSketchText } from "@/components/ui/game-start-countdown";
 import { useEffect, useState } from "react";
-import { useSelector } from "react-redux";
+import { useDispatch, useSelector } from "react-redux";
 import { RootState } from "@/state/store";
 import { getGameRole } from "@/lib/player";
 import { GameRole } from "@/state/features/room";
 import { MessageCircleIcon } from "lucide-react";
+import { RaisedButton } from "@/components/ui/raised-button";
 
 export function DrawingView() {
 	const drawingTime = useSelector(
@@ -77,9 +78,12 @@ export function DrawingView() {
 												<MessageCircleIcon className="size-5 -translate-y-0.5" />
 												Chat
 											</h1>
-											<p className="font-bold text-xl text-muted-foreground">
-												Round {currentRound} of {totalRounds}
-											</p>
+											<div className="flex items-center gap-2">
+												{!isDrawing && <SkipWordButton />}
+												<p className="font-bold text-xl text-muted-foreground">
+													Round {currentRound} of {totalRounds}
+												</p>
+											</div>
 										</div>
 										<div className="flex-1 bg-background-secondary/50 backdrop-blur-sm border-4 border-border border-dashed rounded-xl flex flex-col lg:w-[22rem] w-full lg:h-full h-auto relative overflow-hidden">
 											<div className="w-full bg-gradient-to-b from-background-secondary via-background-secondary to-background-transparent absolute top-0 left-0 h-12 z-50 items-start px-2 py-1.5 justify-between flex sm:hidden">
@@ -88,9 +92,12 @@ export function DrawingView() {
 														<MessageCircleIcon className="size-6 -translate-y-0.5" />
 														Chat
 													</h1>
-													<p className="font-bold text-lg text-muted-foreground">
-														Round {currentRound} of {totalRounds}
-													</p>
+													<div className="flex items-center gap-2">
+														{!isDrawing && <SkipWordButton />}
+														<p className="font-bold text-lg text-muted-foreground">
+															Round {currentRound} of {totalRounds}
+														</p>
+													</div>
 												</div>
 											</div>
 											<Chat
@@ -115,3 +122,23 @@ export function DrawingView() {
 		</SkyScene>
 	);
 }
+
+// Lets guessers vote to skip a drawing nobody can guess
+function SkipWordButton() {
+	const dispatch = useDispatch();
+	const skipVotes = useSelector((state: RootState) => state.game.skipVotes);
+	const playerId = useSelector((state: RootState) => state.room.playerId);
+	const hasVoted = skipVotes.includes(playerId);
+
+	return (
+		<RaisedButton
+			size="sm"
+			variant="basic"
+			shift={false}
+			disabled={hasVoted}
+			onClick={() => dispatch({ type: "game/voteSkipWord" })}
+		>
+			Skip{skipVotes.length > 0 && ` (${skipVotes.length})`}
+		</RaisedButton>
+	);
+}
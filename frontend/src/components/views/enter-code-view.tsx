@@ -122,6 +122,18 @@ export function EnterCodeView() {
 						>
 							Create room
 						</RaisedButton>
+						<RaisedButton
+							size="xl"
+							variant="default"
+							className="w-full"
+							onClick={() => {
+								clearQueryParams();
+								dispatch(enterRoomCode("quickplay"));
+								dispatch(setCurrentState(RoomState.EnterPlayerInfo));
+							}}
+						>
+							Quick play
+						</RaisedButton>
 						<div className="flex items-center gap-2 w-full px-1 -translate-y-0.5">
 							<div className="h-0.5 bg-foreground/20 flex-1 rounded-full" />
 							<p className="text-sm text-muted-foreground font-semibold">or</p>
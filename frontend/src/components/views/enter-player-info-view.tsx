@@ -3,7 +3,8 @@ import { PlayerInfoForm } from "./components/player-info-form";
 import { getRealtimeHref } from "@/lib/realtime";
 import { useDispatch, useSelector } from "react-redux";
 import { RootState } from "@/state/store";
-import { enterRoomCode } from "@/state/features/client";
+import { enterRoomCode, enterRoomPassword } from "@/state/features/client";
+import { Input } from "@/components/ui/input";
 import { StepBackIcon, StepForwardIcon } from "lucide-react";
 import { RaisedButton } from "@/components/ui/raised-button";
 import { RoomState, setCurrentState } from "@/state/features/room";
@@ -18,6 +19,13 @@ export function EnterPlayerInfoView() {
 	const enteredRoomCode = useSelector(
 		(state: RootState) => state.client.roomCode
 	);
+	const roomPassword = useSelector(
+		(state: RootState) => state.client.roomPassword
+	);
+	const isJoiningByCode =
+		!!enteredRoomCode &&
+		enteredRoomCode !== "new" &&
+		enteredRoomCode !== "quickplay";
 
 	useEffect(() => {
 		setIsJoining(false);
@@ -25,10 +33,18 @@ export function EnterPlayerInfoView() {
 
 	function handleSubmit() {
 		setIsJoining(true);
-		if (enteredRoomCode && enteredRoomCode !== "new") {
+		if (enteredRoomCode === "quickplay") {
+			dispatch({
+				type: "socket/connect",
+				payload: getRealtimeHref() + "/quickplay",
+			});
+		} else if (isJoiningByCode) {
+			const query = roomPassword
+				? "?password=" + encodeURIComponent(roomPassword)
+				: "";
 			dispatch({
 				type: "socket/connect",
-				payload: getRealtimeHref() + "/join/" + enteredRoomCode,
+				payload: getRealtimeHref() + "/join/" + enteredRoomCode + query,
 			});
 		} else {
 			dispatch({
@@ -73,6 +89,17 @@ export function EnterPlayerInfoView() {
 					</RaisedButton>
 				}
 			/>
+			{isJoiningByCode && (
+				<Input
+					type="password"
+					autoComplete="off"
+					maxLength={64}
+					value={roomPassword}
+					onChange={(e) => dispatch(enterRoomPassword(e.target.value))}
+					placeholder="Room password (if it has one)"
+					className="max-w-xs z-10"
+				/>
+			)}
 		</SkyScene>
 	);
 }
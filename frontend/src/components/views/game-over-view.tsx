@@ -0,0 +1,130 @@
+import { useDispatch, useSelector } from "react-redux";
+import { RootState } from "@/state/store";
+import { SkyScene } from "@/components/scenes/sky-scene";
+import { RaisedButton } from "@/components/ui/raised-button";
+import { CrownIcon, RotateCcwIcon } from "lucide-react";
+import { cn } from "@/lib/utils";
+import { teamColors, teamNames } from "@/lib/team";
+
+function ordinal(rank: number) {
+	const suffixes: Record<number, string> = { 1: "st", 2: "nd", 3: "rd" };
+	const lastTwo = rank % 100;
+	if (lastTwo >= 11 && lastTwo <= 13) return `${rank}th`;
+	return `${rank}${suffixes[rank % 10] ?? "th"}`;
+}
+
+export function GameOverView() {
+	const standings = useSelector(
+		(state: RootState) => state.game.finalStandings
+	);
+	const teamStandings = useSelector(
+		(state: RootState) => state.game.teamStandings
+	);
+	const currentPlayerId = useSelector(
+		(state: RootState) => state.room.playerId
+	);
+	const rematchVotes = useSelector(
+		(state: RootState) => state.game.rematchVotes
+	);
+	const playerCount = useSelector(
+		(state: RootState) => Object.keys(state.room.players).length
+	);
+	const hasVoted = rematchVotes.includes(currentPlayerId);
+
+	const dispatch = useDispatch();
+
+	return (
+		<SkyScene className="px-4 lg:px-0">
+			<h1 className="text-2xl lg:text-3xl font-bold lg:py-2">
+				Final standings
+			</h1>
+			{teamStandings && (
+				<div className="grid grid-cols-2 gap-4 max-w-2xl w-full">
+					{teamStandings.map((standing) => (
+						<div
+							key={standing.team}
+							className={cn(
+								"flex items-center gap-2 rounded-lg px-4 py-2 text-white shadow-accent-md",
+								teamColors[standing.team]
+							)}
+						>
+							<p className="text-lg font-bold flex items-center gap-1.5">
+								{ordinal(standing.rank)}
+								{standing.rank === 1 && (
+									<CrownIcon className="w-5 h-5 text-yellow-300" />
+								)}
+							</p>
+							<p className="text-lg font-bold truncate">
+								{teamNames[standing.team]}
+							</p>
+							<p className="text-lg font-medium ml-auto">
+								{standing.score} pts
+							</p>
+						</div>
+					))}
+				</div>
+			)}
+			<div
+				className={cn(
+					"relative z-50 flex flex-col items-center justify-start overflow-x-hidden overflow-y-auto",
+					"w-full max-w-2xl max-h-[28rem] gap-3",
+					"lg:px-10 p-4 lg:py-6",
+					"bg-zinc-400/10 border-4 border-border border-dashed rounded-lg scrollbar-hide"
+				)}
+			>
+				{standings?.map((standing) => (
+					<div
+						key={standing.playerId}
+						className="flex lg:gap-6 gap-2 w-full items-center"
+					>
+						<p className="text-lg font-bold text-foreground flex items-center gap-1.5 w-12">
+							{standing.team && (
+								<span
+									className={cn(
+										"size-2.5 rounded-full shrink-0",
+										teamColors[standing.team]
+									)}
+								/>
+							)}
+							{ordinal(standing.rank)}
+							{standing.rank === 1 && (
+								<CrownIcon className="w-5 h-5 text-yellow-400" />
+							)}
+						</p>
+						<div className="flex flex-col min-w-0">
+							<p className="text-xl font-bold text-foreground truncate">
+								{standing.username}{" "}
+								{standing.playerId === currentPlayerId && (
+									<span className="text-xs text-foreground/50 px-0.5">
+										(You)
+									</span>
+								)}
+							</p>
+							<p className="text-sm text-foreground/60 truncate">
+								{standing.correctGuesses} correct{" "}
+								{standing.correctGuesses === 1 ? "guess" : "guesses"}
+								{standing.wordsDrawn.length > 0 &&
+									` · drew ${standing.wordsDrawn.join(", ")}`}
+							</p>
+						</div>
+						<p className="text-lg font-medium text-foreground ml-auto">
+							{standing.score} pts
+						</p>
+					</div>
+				))}
+			</div>
+			<RaisedButton
+				size="lg"
+				variant="action"
+				className="lg:w-48 w-44"
+				disabled={hasVoted}
+				onClick={() => dispatch({ type: "game/voteRematch" })}
+			>
+				<span className="flex items-center gap-2 text-lg">
+					<RotateCcwIcon className="size-4" />
+					Play again ({rematchVotes.length}/{playerCount})
+				</span>
+			</RaisedButton>
+		</SkyScene>
+	);
+}
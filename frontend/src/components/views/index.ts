@@ -2,6 +2,9 @@ import { WaitingView } from "./waiting-view";
 import { PickingView } from "./picking-view";
 import { DrawingView } from "./drawing-view";
 import { PostDrawingView } from "./post-drawing-view";
+import { GameOverView } from "./game-over-view";
+import { TelephoneView } from "./telephone-view";
+import { TelephoneRevealView } from "./telephone-reveal-view";
 import { EnterCodeView } from "./enter-code-view";
 import { EnterPlayerInfoView } from "./enter-player-info-view";
 
@@ -12,4 +15,7 @@ export {
 	PickingView,
 	DrawingView,
 	PostDrawingView,
+	GameOverView,
+	TelephoneView,
+	TelephoneRevealView,
 };
@@ -8,6 +8,8 @@ import { cn } from "@/lib/utils";
 import { AnimatedNumber } from "@/components/ui/animated-number";
 import { motion } from "motion/react";
 import { getDrawingPlayer } from "@/lib/player";
+import { GameMode } from "@/state/features/room";
+import { TeamScores } from "@/components/views/components/team-scores";
 const springConfig = {
 	type: "spring",
 	stiffness: 100,
@@ -58,6 +60,10 @@ export function PostDrawingView() {
 
 	const drawingPlayer = getDrawingPlayer(players);
 
+	const isTeamGame = useSelector(
+		(state: RootState) => state.room.settings.gameMode === GameMode.Teams
+	);
+
 	const message = drawingPlayer
 		? `${drawingPlayer.username} sketched: `
 		: "The word was: ";
@@ -68,6 +74,7 @@ export function PostDrawingView() {
 				{message}
 				<span className="lg:text-3xl text-xl font-bold">{word?.value}</span>
 			</h1>
+			{isTeamGame && <TeamScores />}
 			<Podium players={sortedPlayers.slice(0, 3)} placeChanges={placeChanges} />
 			{sortedPlayers.length > 3 && (
 				<Leaderboard
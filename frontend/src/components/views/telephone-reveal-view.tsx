@@ -0,0 +1,60 @@
+import Canvas from "./components/canvas";
+import { SkyScene } from "@/components/scenes/sky-scene";
+import { useSelector } from "react-redux";
+import { RootState } from "@/state/store";
+import { TelephoneStepKind } from "@/state/features/telephone";
+import { cn } from "@/lib/utils";
+
+// Walks through each chain one entry at a time, the server decides when to move on
+export function TelephoneRevealView() {
+	const chains = useSelector((state: RootState) => state.telephone.chains);
+	const reveal = useSelector((state: RootState) => state.telephone.reveal);
+	const players = useSelector((state: RootState) => state.room.players);
+
+	const chain = chains[reveal.chain];
+	const entry = chain?.entries[reveal.entry];
+	if (!chain || !entry) return null;
+
+	const username = (playerId: string) =>
+		players[playerId]?.username ?? "Someone who left";
+
+	return (
+		<SkyScene className="px-4 lg:px-0">
+			<p className="font-bold text-lg text-muted-foreground">
+				Chain {reveal.chain + 1} of {chains.length}
+			</p>
+			<h1 className="text-2xl lg:text-3xl font-bold text-center">
+				{username(chain.ownerId)}'s chain
+			</h1>
+			<div className="flex flex-wrap justify-center gap-2 max-w-3xl">
+				{chain.entries.slice(0, reveal.entry + 1).map((e, i) => (
+					<span
+						key={i}
+						className={cn(
+							"rounded-lg px-3 py-1 text-sm font-semibold bg-background shadow-accent",
+							i === reveal.entry && "bg-primary text-background"
+						)}
+					>
+						{e.kind === TelephoneStepKind.Drawing
+							? `${username(e.playerId)} sketched`
+							: e.text || "..."}
+					</span>
+				))}
+			</div>
+			{entry.kind === TelephoneStepKind.Drawing ? (
+				<>
+					<p className="text-lg font-semibold">
+						{username(entry.playerId)} sketched:
+					</p>
+					<Canvas padding={10} width={800} height={600} />
+				</>
+			) : (
+				<p className="text-xl lg:text-2xl font-semibold text-center">
+					{username(entry.playerId)}{" "}
+					{entry.kind === TelephoneStepKind.Prompt ? "wrote" : "guessed"}:{" "}
+					<span className="font-bold">{entry.text || "nothing"}</span>
+				</p>
+			)}
+		</SkyScene>
+	);
+}
@@ -0,0 +1,126 @@
+import Canvas from "./components/canvas";
+import { CanvasTools, ColorSliders } from "./components/canvas-tools";
+import { SkyScene } from "@/components/scenes/sky-scene";
+import { RaisedButton } from "@/components/ui/raised-button";
+import { RaisedInput } from "@/components/ui/raised-input";
+import { useState } from "react";
+import { useDispatch, useSelector } from "react-redux";
+import { RootState } from "@/state/store";
+import { TelephoneStepKind } from "@/state/features/telephone";
+import { RoomRole } from "@/state/features/room";
+
+// Longest prompt or description the server accepts
+const MAX_TEXT_LENGTH = 100;
+
+const instructions: Record<TelephoneStepKind, string> = {
+	[TelephoneStepKind.Prompt]: "Write something for the next player to sketch",
+	[TelephoneStepKind.Drawing]: "Sketch this:",
+	[TelephoneStepKind.Description]: "Describe this sketch",
+};
+
+export function TelephoneView() {
+	const task = useSelector((state: RootState) => state.telephone.task);
+	const submitted = useSelector(
+		(state: RootState) => state.telephone.submitted
+	);
+	const playerId = useSelector((state: RootState) => state.room.playerId);
+	const playerCount = useSelector(
+		(state: RootState) =>
+			Object.values(state.room.players).filter(
+				(player) =>
+					player.roomRole !== RoomRole.Spectator && !player.sittingOut
+			).length
+	);
+
+	// Players who joined after the game started sit this one out
+	if (!task) {
+		return (
+			<SkyScene>
+				<h1 className="text-2xl lg:text-3xl font-bold px-8 lg:px-0 text-center">
+					Everyone is busy sketching, the chains will be revealed soon
+				</h1>
+			</SkyScene>
+		);
+	}
+
+	const waitingFor = Math.max(playerCount - submitted.length, 0);
+
+	return (
+		<SkyScene className="px-4 lg:px-0">
+			<p className="font-bold text-lg text-muted-foreground">
+				Step {task.step + 1} of {task.totalSteps}
+			</p>
+			<h1 className="text-2xl lg:text-3xl font-bold text-center">
+				{instructions[task.kind]}
+				{task.kind === TelephoneStepKind.Drawing && (
+					<span className="ml-2">{task.prompt || "anything you like"}</span>
+				)}
+			</h1>
+			{submitted.includes(playerId) ? (
+				<p className="text-lg font-semibold text-muted-foreground">
+					Waiting for {waitingFor} more{" "}
+					{waitingFor === 1 ? "player" : "players"}...
+				</p>
+			) : task.kind === TelephoneStepKind.Drawing ? (
+				<DrawingTask />
+			) : (
+				<TextTask showCanvas={task.kind === TelephoneStepKind.Description} />
+			)}
+		</SkyScene>
+	);
+}
+
+// The player's own canvas, nobody else sees it until the reveal
+function DrawingTask() {
+	const dispatch = useDispatch();
+
+	return (
+		<div className="flex items-center justify-center gap-6 pb-1">
+			<ColorSliders />
+			<div className="flex flex-col gap-2 items-center">
+				<Canvas padding={10} width={800} height={600} />
+				<CanvasTools />
+				<RaisedButton
+					size="wide"
+					onClick={() => dispatch({ type: "telephone/submit" })}
+				>
+					Done
+				</RaisedButton>
+			</div>
+		</div>
+	);
+}
+
+// Writing a prompt, or describing the sketch on the canvas
+function TextTask({ showCanvas }: { showCanvas: boolean }) {
+	const dispatch = useDispatch();
+	const [text, setText] = useState("");
+
+	const submit = (e: React.FormEvent) => {
+		e.preventDefault();
+		if (text.trim().length === 0) return;
+		dispatch({ type: "telephone/submit", payload: text.trim() });
+	};
+
+	return (
+		<div className="flex flex-col gap-4 items-center w-full max-w-3xl">
+			{showCanvas && <Canvas padding={10} width={800} height={600} />}
+			<form onSubmit={submit} className="flex gap-4 w-full max-w-xl">
+				<RaisedInput
+					autoFocus
+					value={text}
+					maxLength={MAX_TEXT_LENGTH}
+					placeholder={showCanvas ? "This is a..." : "A cat riding a bike"}
+					onChange={(e) => setText(e.target.value)}
+				/>
+				<RaisedButton
+					size="lg"
+					type="submit"
+					disabled={text.trim().length === 0}
+				>
+					Done
+				</RaisedButton>
+			</form>
+		</div>
+	);
+}
@@ -11,6 +11,7 @@ import { ModalMenu } from "@/components/ui/modal-menu";
 import { AnimatePresence } from "motion/react";
 import { RoomRole } from "@/state/features/room";
 import { RoomSettingsForm } from "./components/room-settings-form";
+import { RoomPasswordForm } from "./components/room-password-form";
 import { Chat } from "./components/chat";
 
 export function WaitingView() {
@@ -81,6 +82,7 @@ export function WaitingView() {
 								Room settings
 							</h1>
 							<RoomSettingsForm />
+							<RoomPasswordForm />
 						</div>
 					) : (
 						<div className="flex h-full gap-2 flex-1 lg:flex-row flex-col lg:divide-x-4 lg:divide-y-0 divide-y-4 divide-border divide-dashed">
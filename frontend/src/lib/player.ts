@@ -1,4 +1,4 @@
-import { GameRole } from "@/state/features/game";
+import { GameRole, RelayTurn } from "@/state/features/game";
 import { Player } from "@/state/features/room";
 
 export function getRoomRole(playerId: string, players: Record<string, Player>) {
@@ -12,3 +12,27 @@ export function getGameRole(playerId: string, players: Record<string, Player>) {
 export function getDrawingPlayer(players: Record<string, Player>) {
 	return Object.values(players).find((p) => p.gameRole === GameRole.Drawing);
 }
+
+export function getDrawingPlayers(players: Record<string, Player>) {
+	return Object.values(players).filter((p) => p.gameRole === GameRole.Drawing);
+}
+
+// Drawers in a relay game take turns, the canvas is only theirs on their turn
+export function getCanvasRole(
+	playerId: string,
+	players: Record<string, Player>,
+	relayTurn: RelayTurn | null
+) {
+	if (relayTurn && relayTurn.playerId !== playerId) {
+		return GameRole.Guessing;
+	}
+	return getGameRole(playerId, players);
+}
+
+// Joins names into a list like "Alice, Bob and Carol"
+export function joinNames(names: string[]) {
+	if (names.length <= 1) {
+		return names.join("");
+	}
+	return `${names.slice(0, -1).join(", ")} and ${names[names.length - 1]}`;
+}
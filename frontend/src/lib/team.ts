@@ -0,0 +1,24 @@
+import { Player, Team } from "@/state/features/room";
+
+export const teams = [Team.Red, Team.Blue];
+
+export const teamNames: Record<Team, string> = {
+	[Team.Red]: "Red team",
+	[Team.Blue]: "Blue team",
+};
+
+export const teamColors: Record<Team, string> = {
+	[Team.Red]: "bg-red-500",
+	[Team.Blue]: "bg-sky-500",
+};
+
+// A team's score is the sum of its players' scores
+export function getTeamScores(players: Record<string, Player>) {
+	const scores: Record<Team, number> = { [Team.Red]: 0, [Team.Blue]: 0 };
+	for (const player of Object.values(players)) {
+		if (player.team) {
+			scores[player.team] += player.score;
+		}
+	}
+	return scores;
+}
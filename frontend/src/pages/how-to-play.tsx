@@ -49,8 +49,27 @@ export function HowToPlay() {
 						<li>
 							No Hints - Play without letter reveals for an extra challenge
 						</li>
+						<li>
+							Teams - Split into two teams that take turns drawing. Only your
+							teammates can guess your drawing at first, halfway through the
+							other team can steal it
+						</li>
+						<li>
+							Telephone - Everyone writes a prompt, then the prompts get passed
+							around with players taking turns sketching the text they're
+							handed and describing the sketch they're handed. At the end every
+							chain is revealed to see how far it drifted
+						</li>
+						<li>
+							Relay - A few players share each word and take turns adding to
+							the same sketch, splitting the points when it gets guessed
+						</li>
 					</ul>
 				</li>
+				<li>
+					<strong>Scoring:</strong> Classic gives earlier guessers more points,
+					Timed gives more points the faster you guess
+				</li>
 				<li>
 					<strong>Word Difficulty:</strong> Choose from Random, Easy, Medium, or
 					Hard words
@@ -29,6 +29,12 @@ export const canvasSlice = createSlice({
 			}
 			state.strokes[state.strokes.length - 1].points.push(action.payload);
 		},
+		addStrokePoints: (state, action: PayloadAction<number[][]>) => {
+			if (state.strokes.length === 0) {
+				return;
+			}
+			state.strokes[state.strokes.length - 1].points.push(...action.payload);
+		},
 		clearStrokes: (state) => {
 			state.strokes = [];
 		},
@@ -41,7 +47,12 @@ export const canvasSlice = createSlice({
 	},
 });
 
-export const { addStroke, addStrokePoint, clearStrokes, undoStroke } =
-	canvasSlice.actions;
+export const {
+	addStroke,
+	addStrokePoint,
+	addStrokePoints,
+	clearStrokes,
+	undoStroke,
+} = canvasSlice.actions;
 
 export default canvasSlice.reducer;
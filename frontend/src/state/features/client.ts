@@ -22,6 +22,7 @@ export interface ClientState {
 	avatarConfig: AvatarConfig;
 	customWords: string[];
 	roomCode: string;
+	roomPassword: string;
 }
 
 const initialState: ClientState = {
@@ -38,6 +39,7 @@ const initialState: ClientState = {
 	avatarConfig: Avatar.random(),
 	customWords: [],
 	roomCode: "",
+	roomPassword: "",
 };
 
 export const clientSlice = createSlice({
@@ -121,6 +123,9 @@ export const clientSlice = createSlice({
 		enterRoomCode: (state, action: PayloadAction<string>) => {
 			state.roomCode = action.payload;
 		},
+		enterRoomPassword: (state, action: PayloadAction<string>) => {
+			state.roomPassword = action.payload;
+		},
 	},
 });
 
@@ -136,6 +141,7 @@ export const {
 	changeUsername,
 	changeCustomWords,
 	enterRoomCode,
+	enterRoomPassword,
 } = clientSlice.actions;
 
 export default clientSlice.reducer;
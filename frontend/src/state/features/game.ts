@@ -1,21 +1,62 @@
 import { createSlice, PayloadAction } from "@reduxjs/toolkit";
-import { Word } from "./room";
+import { Team, Word } from "./room";
 
 export enum GameRole {
 	Drawing = "drawing",
 	Guessing = "guessing",
 }
 
+export interface FinalStanding {
+	playerId: string;
+	username: string;
+	rank: number;
+	score: number;
+	wordsDrawn: string[];
+	correctGuesses: number;
+	team?: Team;
+}
+
+export interface TeamStanding {
+	team: Team;
+	rank: number;
+	score: number;
+	// IDs of the team's players, sorted by score
+	players: string[];
+}
+
+// Whose turn it is to draw in a relay game
+export interface RelayTurn {
+	playerId: string;
+	endsAt: string;
+}
+
 export interface GameState {
 	wordOptions: Word[];
 	selectedWord: Word | null;
 	pointsAwarded: Record<string, number>;
+	// Only set in team games
+	teamPointsAwarded: Record<Team, number> | null;
+	// Results of the last game, kept until the next game starts
+	finalStandings: FinalStanding[] | null;
+	teamStandings: TeamStanding[] | null;
+	// IDs of the players who voted to play again
+	rematchVotes: string[];
+	// IDs of the guessers who voted to skip the current drawing
+	skipVotes: string[];
+	// Only set while drawing in relay games
+	relayTurn: RelayTurn | null;
 }
 
 const initialState: GameState = {
 	wordOptions: [],
 	selectedWord: null,
 	pointsAwarded: {},
+	teamPointsAwarded: null,
+	finalStandings: null,
+	teamStandings: null,
+	rematchVotes: [],
+	skipVotes: [],
+	relayTurn: null,
 };
 
 export const gameSlice = createSlice({
@@ -29,16 +70,49 @@ export const gameSlice = createSlice({
 		) => {
 			state.pointsAwarded = action.payload;
 		},
+		setTeamPointsAwarded: (
+			state,
+			action: PayloadAction<Record<Team, number> | null>
+		) => {
+			state.teamPointsAwarded = action.payload;
+		},
 		setWordOptions: (state, action: PayloadAction<Word[]>) => {
 			state.wordOptions = action.payload;
 		},
 		selectWord: (state, action: PayloadAction<Word>) => {
 			state.selectedWord = action.payload;
 		},
+		setFinalStandings: (
+			state,
+			action: PayloadAction<FinalStanding[] | null>
+		) => {
+			state.finalStandings = action.payload;
+		},
+		setTeamStandings: (state, action: PayloadAction<TeamStanding[] | null>) => {
+			state.teamStandings = action.payload;
+		},
+		setRematchVotes: (state, action: PayloadAction<string[]>) => {
+			state.rematchVotes = action.payload;
+		},
+		setSkipVotes: (state, action: PayloadAction<string[]>) => {
+			state.skipVotes = action.payload;
+		},
+		setRelayTurn: (state, action: PayloadAction<RelayTurn | null>) => {
+			state.relayTurn = action.payload;
+		},
 	},
 });
 
-export const { setPointsAwarded, setWordOptions, selectWord } =
-	gameSlice.actions;
+export const {
+	setPointsAwarded,
+	setTeamPointsAwarded,
+	setWordOptions,
+	selectWord,
+	setFinalStandings,
+	setTeamStandings,
+	setRematchVotes,
+	setSkipVotes,
+	setRelayTurn,
+} = gameSlice.actions;
 
 export default gameSlice.reducer;
@@ -6,6 +6,7 @@ export enum ChatMessageType {
 	Correct = "correct",
 	CloseGuess = "close_guess",
 	System = "system",
+	Spectator = "spectator",
 }
 
 export type ChatMessage = {
@@ -17,6 +18,7 @@ export type ChatMessage = {
 export enum RoomRole {
 	Host = "host",
 	Player = "player",
+	Spectator = "spectator",
 }
 
 export enum GameRole {
@@ -24,6 +26,11 @@ export enum GameRole {
 	Guessing = "guessing",
 }
 
+export enum Team {
+	Red = "red",
+	Blue = "blue",
+}
+
 export type Player = {
 	id: string;
 	username: string;
@@ -32,6 +39,12 @@ export type Player = {
 	gameRole: GameRole;
 	score: number;
 	streak: number;
+	// Set for players who didn't vote for a rematch, they don't draw or score
+	sittingOut: boolean;
+	// Muted players' chat messages are dropped by the server
+	muted: boolean;
+	// Only set in team games
+	team?: Team;
 };
 
 export enum WordBank {
@@ -43,6 +56,16 @@ export enum WordBank {
 export enum GameMode {
 	Classic = "classic",
 	NoHints = "noHints",
+	Teams = "teams",
+	Telephone = "telephone",
+	Relay = "relay",
+}
+
+export enum ScoringMode {
+	// Earlier guessers get more points
+	Classic = "classic",
+	// Faster guesses get more points
+	Timed = "timed",
 }
 
 export enum WordDifficulty {
@@ -67,6 +90,20 @@ type RoomSettings = {
 	wordBank: WordBank;
 	customWords: Word[];
 	gameMode: GameMode;
+	scoringMode: ScoringMode;
+	// Public rooms show up in the room browser and quick play
+	public: boolean;
+	// Only sent to the server to change the password, it never sends it back
+	password?: string;
+	hasPassword: boolean;
+};
+
+export type VoteKick = {
+	targetId: string;
+	startedBy: string;
+	votes: string[];
+	needed: number;
+	endsAt: string; // utc date string
 };
 
 export enum RoomState {
@@ -79,6 +116,8 @@ export enum RoomState {
 	Drawing = 201,
 	PostDrawing = 202,
 	GameOver = 203,
+	Telephone = 204,
+	TelephoneReveal = 205,
 }
 
 export interface Room {
@@ -87,6 +126,9 @@ export interface Room {
 	players: { [key: string]: Player };
 	currentRound: number;
 	chatMessages: ChatMessage[];
+	// Only spectators receive messages from other spectators
+	spectatorChat: ChatMessage[];
+	voteKick: VoteKick | null;
 
 	currentState: RoomState;
 	previousState: RoomState;
@@ -94,11 +136,13 @@ export interface Room {
 	timerEndsAt: string; // utc date string
 
 	playerId: string;
+	resumeToken: string;
 }
 
 const initialState: Room = {
 	id: "",
 	playerId: "",
+	resumeToken: "",
 	settings: {
 		playerLimit: 6,
 		drawingTimeAllowed: 90,
@@ -107,6 +151,9 @@ const initialState: Room = {
 		wordBank: WordBank.Mixed,
 		customWords: [],
 		gameMode: GameMode.Classic,
+		scoringMode: ScoringMode.Classic,
+		public: false,
+		hasPassword: false,
 	},
 	players: {},
 	timerEndsAt: "",
@@ -115,6 +162,8 @@ const initialState: Room = {
 	transitionCount: 0,
 	currentRound: 0,
 	chatMessages: [],
+	spectatorChat: [],
+	voteKick: null,
 };
 
 export const roomSlice = createSlice({
@@ -143,12 +192,29 @@ export const roomSlice = createSlice({
 			state.currentRound = action.payload.currentRound;
 			state.chatMessages = action.payload.chatMessages;
 		},
-		setPlayerId: (state, action: PayloadAction<string>) => {
-			state.playerId = action.payload;
+		setPlayerId: (
+			state,
+			action: PayloadAction<{ id: string; resumeToken: string }>
+		) => {
+			state.playerId = action.payload.id;
+			state.resumeToken = action.payload.resumeToken;
 		},
 		setPlayers: (state, action: PayloadAction<{ [key: string]: Player }>) => {
 			state.players = action.payload;
 		},
+		hostChanged: (
+			state,
+			action: PayloadAction<{ previousHostId: string; hostId: string }>
+		) => {
+			const previousHost = state.players[action.payload.previousHostId];
+			if (previousHost) {
+				previousHost.roomRole = RoomRole.Player;
+			}
+			const host = state.players[action.payload.hostId];
+			if (host) {
+				host.roomRole = RoomRole.Host;
+			}
+		},
 		playerJoined: (state, action: PayloadAction<Player>) => {
 			state.players[action.payload.id] = action.payload;
 		},
@@ -176,6 +242,15 @@ export const roomSlice = createSlice({
 		newChatMessage: (state, action: PayloadAction<ChatMessage>) => {
 			state.chatMessages.push(action.payload);
 		},
+		setSpectatorChat: (state, action: PayloadAction<ChatMessage[]>) => {
+			state.spectatorChat = action.payload;
+		},
+		newSpectatorChatMessage: (state, action: PayloadAction<ChatMessage>) => {
+			state.spectatorChat.push(action.payload);
+		},
+		setVoteKick: (state, action: PayloadAction<VoteKick | null>) => {
+			state.voteKick = action.payload;
+		},
 		setCurrentRound: (state, action: PayloadAction<number>) => {
 			state.currentRound = action.payload;
 		},
@@ -0,0 +1,74 @@
+import { createSlice, PayloadAction } from "@reduxjs/toolkit";
+
+export enum TelephoneStepKind {
+	Prompt = "prompt",
+	Drawing = "drawing",
+	Description = "description",
+}
+
+// What the player has to do in the current step
+export interface TelephoneTask {
+	step: number;
+	totalSteps: number;
+	kind: TelephoneStepKind;
+	// The text to draw in drawing steps
+	prompt?: string;
+	submitted: boolean;
+}
+
+// Drawings aren't included, they're sent to the canvas as they're revealed
+export interface TelephoneEntry {
+	playerId: string;
+	kind: TelephoneStepKind;
+	text?: string;
+}
+
+export interface TelephoneChain {
+	ownerId: string;
+	entries: TelephoneEntry[];
+}
+
+export interface TelephoneState {
+	// Not set for players who joined after the game started
+	task: TelephoneTask | null;
+	// IDs of the players who handed in the current step
+	submitted: string[];
+	chains: TelephoneChain[];
+	// The entry currently shown in the reveal
+	reveal: { chain: number; entry: number };
+}
+
+const initialState: TelephoneState = {
+	task: null,
+	submitted: [],
+	chains: [],
+	reveal: { chain: 0, entry: 0 },
+};
+
+export const telephoneSlice = createSlice({
+	name: "telephone",
+	initialState,
+	reducers: {
+		reset: () => initialState,
+		setTask: (state, action: PayloadAction<TelephoneTask | null>) => {
+			state.task = action.payload;
+		},
+		setSubmitted: (state, action: PayloadAction<string[]>) => {
+			state.submitted = action.payload;
+		},
+		setChains: (state, action: PayloadAction<TelephoneChain[]>) => {
+			state.chains = action.payload;
+		},
+		setReveal: (
+			state,
+			action: PayloadAction<{ chain: number; entry: number }>
+		) => {
+			state.reveal = action.payload;
+		},
+	},
+});
+
+export const { setTask, setSubmitted, setChains, setReveal } =
+	telephoneSlice.actions;
+
+export default telephoneSlice.reducer;
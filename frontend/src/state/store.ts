@@ -3,6 +3,7 @@ import canvasReducer from "./features/canvas";
 import roomReducer from "./features/room";
 import gameReducer from "./features/game";
 import clientReducer from "./features/client";
+import telephoneReducer from "./features/telephone";
 import { clearQueryParams } from "@/lib/params";
 import { toast } from "sonner";
 import localStorage from "redux-persist/es/storage";
@@ -12,11 +13,49 @@ import { persistReducer, persistStore } from "redux-persist";
 const ErrorMessages = {
 	ErrRoomNotFound: "Room not found",
 	ErrRoomFull: "Room is full",
+	ErrSpectatorsFull: "Too many people are already watching this room",
+	ErrRoomEmpty: "Room closed because all the players left",
 	ErrRoomClosed: "Room closed",
 	ErrConnectionTimeout: "Connection timed out",
 	ErrRoomIdle: "Room closed because it was inactive for too long",
+	ErrRoomUnresponsive: "Room closed because it stopped responding",
 	ErrPlayerIdle: "You were inactive for too long and were kicked",
 	ErrNameTooLong: "Name is too long",
+	ErrTooManyRooms: "You've started too many rooms, try again later",
+	ErrInvalidResumeToken: "Could not rejoin the room",
+	ErrSessionExpired: "You were away for too long and left the room",
+	ErrSessionReplaced: "You rejoined the room from another connection",
+	ErrSlowConsumer: "Your connection is too slow to keep up with the game",
+	ErrKicked: "You were kicked from the room by the host",
+	ErrBanned: "You are banned from this room",
+	ErrVoteKicked: "You were voted out of the room, try again later",
+	ErrPasswordRequired: "This room needs a password",
+	ErrWrongPassword: "Wrong room password",
+	ErrTooManyPasswordAttempts: "Too many wrong passwords, try again later",
+};
+
+// Used to explain to the player why an action they took was rejected.
+// Codes not listed here are ignored to avoid noisy toasts.
+const CommandErrorMessages = {
+	ErrNotEnoughPlayers: "You need at least 2 players to start the game",
+	ErrNotEnoughCustomWords: "You need at least 3 custom words to start the game",
+	ErrWrongRoomRole: "Only the host can do that",
+	ErrInvalidSettings: "Those room settings are not valid",
+	ErrCanvasFull: "You can't draw any more this round",
+	ErrNotSpectator: "That player is not spectating",
+	ErrRoomFull: "There are no free seats in the game",
+	ErrPlayerNotFound: "That player already left the room",
+	ErrMuted: "You have been muted by the host",
+	ErrVoteKickInProgress: "There is already a vote kick in progress",
+	ErrVoteKickCooldown: "Wait a bit before starting another vote kick",
+	ErrNotTeamGame: "The room is not playing in teams",
+	ErrInvalidTeam: "That team doesn't exist",
+	ErrNotEnoughTeamPlayers: "Each team needs at least 2 players to start the game",
+	ErrStealNotOpen: "Your team can't steal this drawing yet",
+	ErrAlreadySubmitted: "You already handed this in",
+	ErrInvalidTelephoneEntry: "Write between 1 and 100 characters",
+	ErrNotYourTurn: "Wait for your turn to draw",
+	ErrNotEnoughRelayPlayers: "You need at least 3 players to start a relay game",
 };
 
 const socketMiddleware: Middleware = (store) => {
@@ -27,6 +66,7 @@ const socketMiddleware: Middleware = (store) => {
 			store.dispatch({ type: "room/reset", fromServer: true });
 			store.dispatch({ type: "game/reset", fromServer: true });
 			store.dispatch({ type: "canvas/reset", fromServer: true });
+			store.dispatch({ type: "telephone/reset", fromServer: true });
 		}, 100);
 	}
 
@@ -77,6 +117,14 @@ const socketMiddleware: Middleware = (store) => {
 							action.type === "info"
 						) {
 							toast(action.payload || "Unknown error occurred");
+						} else if (action.type === "command/error") {
+							const errorMessage =
+								CommandErrorMessages[
+									action.payload.code as keyof typeof CommandErrorMessages
+								];
+							if (errorMessage) {
+								toast.error(errorMessage);
+							}
 						} else {
 							store.dispatch({
 								...action,
@@ -127,6 +175,7 @@ const persistedReducer = persistReducer(
 		room: roomReducer,
 		game: gameReducer,
 		client: clientReducer,
+		telephone: telephoneReducer,
 	})
 );
 
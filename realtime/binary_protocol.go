@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// The binary protocol is a compact encoding for canvas commands and events,
+// which make up most of the traffic during a game.
+//
+// Clients opt in by requesting the BinarySubprotocol when they connect. Only
+// canvas messages are sent as binary websocket messages, everything else is
+// still sent as JSON text messages on the same connection.
+//
+// A binary message is a sequence of records, each starting with an opcode:
+//
+//	addStroke    color:string width:varint type:string points
+//	strokePoints points
+//	clearStrokes
+//	undoStroke
+//	setStrokes   count:uvarint (color:string width:varint type:string points)*
+//
+// Strings are a uvarint length followed by the bytes. Points are encoded as
+// the number of coordinates per point and the number of points as uvarints,
+// followed by each coordinate as a zigzag varint delta from the same coordinate
+// of the previous point. The first point is a delta from zero.
+//
+// Stroke points sent by the drawer over the binary protocol are handled
+// exactly like the same batch sent as a JSON canvas/addStrokePoints command.
+
+const (
+	binaryOpAddStroke    byte = 1
+	binaryOpStrokePoints byte = 2
+	binaryOpClearStrokes byte = 3
+	binaryOpUndoStroke   byte = 4
+	binaryOpSetStrokes   byte = 5
+)
+
+var ErrInvalidBinaryMessage = errors.New("invalid binary message")
+
+// Checks if an event can be sent over the binary protocol
+func isBinaryEvent(evt *Event) bool {
+	switch evt.Type {
+	case AddStrokeEvt, AddStrokePointEvt, AddStrokePointsEvt, ClearStrokesEvt, UndoStrokeEvt, SetStrokesEvt:
+		return true
+	}
+	return false
+}
+
+// Appends the binary encoding of a canvas event to buf.
+// Returns false if the event can't be encoded, so it should be sent as JSON instead.
+func appendBinaryEvent(buf []byte, evt *Event) ([]byte, bool) {
+	switch evt.Type {
+	case AddStrokeEvt:
+		stroke, ok := evt.Payload.(Stroke)
+		if !ok {
+			return buf, false
+		}
+		return appendStroke(append(buf, binaryOpAddStroke), stroke)
+	case AddStrokePointEvt, AddStrokePointsEvt:
+		points, ok := strokePoints(evt)
+		if !ok {
+			return buf, false
+		}
+		return appendPoints(append(buf, binaryOpStrokePoints), points)
+	case ClearStrokesEvt:
+		return append(buf, binaryOpClearStrokes), true
+	case UndoStrokeEvt:
+		return append(buf, binaryOpUndoStroke), true
+	case SetStrokesEvt:
+		strokes, ok := evt.Payload.([]Stroke)
+		if !ok {
+			return buf, false
+		}
+		buf = binary.AppendUvarint(append(buf, binaryOpSetStrokes), uint64(len(strokes)))
+		for _, stroke := range strokes {
+			if buf, ok = appendStroke(buf, stroke); !ok {
+				return buf, false
+			}
+		}
+		return buf, true
+	}
+	return buf, false
+}
+
+func appendStroke(buf []byte, stroke Stroke) ([]byte, bool) {
+	buf = appendString(buf, stroke.Color)
+	buf = binary.AppendVarint(buf, int64(stroke.Width))
+	buf = appendString(buf, stroke.Type)
+	return appendPoints(buf, stroke.Points)
+}
+
+// Appends delta encoded points. All points must have the same number of coordinates.
+func appendPoints(buf []byte, points [][]int) ([]byte, bool) {
+	dims := 0
+	if len(points) > 0 {
+		dims = len(points[0])
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(dims))
+	buf = binary.AppendUvarint(buf, uint64(len(points)))
+
+	prev := make([]int, dims)
+	for _, point := range points {
+		if len(point) != dims {
+			return buf, false
+		}
+		for i, v := range point {
+			buf = binary.AppendVarint(buf, int64(v-prev[i]))
+			prev[i] = v
+		}
+	}
+	return buf, true
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// Decodes a binary message from a client into commands.
+func decodeBinaryCommands(msg []byte) ([]*Command, error) {
+	r := &binaryReader{buf: msg}
+	commands := make([]*Command, 0, 1)
+
+	for len(r.buf) > 0 && r.err == nil {
+		switch op := r.byte(); op {
+		case binaryOpAddStroke:
+			commands = append(commands, &Command{Type: AddStrokeCmd, Payload: r.stroke()})
+		case binaryOpStrokePoints:
+			commands = append(commands, &Command{Type: AddStrokePointsCmd, Payload: r.points()})
+		case binaryOpClearStrokes:
+			commands = append(commands, &Command{Type: ClearStrokesCmd})
+		case binaryOpUndoStroke:
+			commands = append(commands, &Command{Type: UndoStrokeCmd})
+		default:
+			r.fail(fmt.Sprintf("unknown opcode %d", op))
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return commands, nil
+}
+
+// Reads values from a binary message, remembering the first error it runs into
+// so callers can check once at the end.
+type binaryReader struct {
+	buf []byte
+	err error
+}
+
+func (r *binaryReader) fail(reason string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: %s", ErrInvalidBinaryMessage, reason)
+	}
+	r.buf = nil
+}
+
+func (r *binaryReader) byte() byte {
+	if len(r.buf) == 0 {
+		r.fail("unexpected end of message")
+		return 0
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.fail("malformed uvarint")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *binaryReader) varint() int64 {
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.fail("malformed varint")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *binaryReader) string() string {
+	n := r.uvarint()
+	if n > uint64(len(r.buf)) {
+		r.fail("string longer than message")
+		return ""
+	}
+	s := string(r.buf[:n])
+	r.buf = r.buf[n:]
+	return s
+}
+
+func (r *binaryReader) points() [][]int {
+	dims := r.uvarint()
+	count := r.uvarint()
+
+	// Every coordinate takes at least one byte, so this also
+	// stops clients from making us allocate huge slices.
+	available := uint64(len(r.buf))
+	if count > 0 && (dims == 0 || dims > available || count > available/dims) {
+		r.fail("point count larger than message")
+		return nil
+	}
+
+	points := make([][]int, 0, count)
+	prev := make([]int, dims)
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		point := make([]int, dims)
+		for d := range point {
+			point[d] = prev[d] + int(r.varint())
+			prev[d] = point[d]
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+func (r *binaryReader) stroke() Stroke {
+	var stroke Stroke
+	stroke.Color = r.string()
+	stroke.Width = int(r.varint())
+	stroke.Type = r.string()
+	stroke.Points = r.points()
+	return stroke
+}
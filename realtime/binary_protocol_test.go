@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBinaryProtocol_DecodeCommands(t *testing.T) {
+	stroke := Stroke{
+		Points: [][]int{{10, 20}, {12, 18}, {-5, 300}},
+		Color:  "#ff0000",
+		Width:  8,
+		Type:   "brush",
+	}
+
+	// Clients encode commands the same way the server encodes events
+	msg, _ := appendBinaryEvent(nil, event(AddStrokeEvt, stroke))
+	msg, _ = appendBinaryEvent(msg, event(AddStrokePointsEvt, [][]int{{13, 17}, {14, 16}}))
+	msg, _ = appendBinaryEvent(msg, event(UndoStrokeEvt, nil))
+	msg, _ = appendBinaryEvent(msg, event(ClearStrokesEvt, nil))
+
+	commands, err := decodeBinaryCommands(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []*Command{
+		{Type: AddStrokeCmd, Payload: stroke},
+		{Type: AddStrokePointsCmd, Payload: [][]int{{13, 17}, {14, 16}}},
+		{Type: UndoStrokeCmd},
+		{Type: ClearStrokesCmd},
+	}
+	if !reflect.DeepEqual(commands, want) {
+		t.Errorf("decoded commands do not match\n got: %+v\nwant: %+v", commands, want)
+	}
+}
+
+func TestBinaryProtocol_RejectsMalformedMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  []byte
+	}{
+		{"unknown opcode", []byte{99}},
+		{"truncated points", []byte{binaryOpStrokePoints, 2, 3, 1}},
+		{"huge point count", []byte{binaryOpStrokePoints, 2, 0xff, 0xff, 0xff, 0xff, 0x0f, 1, 1}},
+		{"huge dimensions", []byte{binaryOpStrokePoints, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 2, 1}},
+		{"string longer than message", []byte{binaryOpAddStroke, 50, 'a'}},
+		{"set strokes is not a command", []byte{binaryOpSetStrokes, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeBinaryCommands(tt.msg); !errors.Is(err, ErrInvalidBinaryMessage) {
+				t.Errorf("expected ErrInvalidBinaryMessage, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBinaryProtocol_PointsAreCompact(t *testing.T) {
+	points := make([][]int, 50)
+	for i := range points {
+		points[i] = []int{400 + i, 300 - i}
+	}
+
+	msg, ok := appendBinaryEvent(nil, event(AddStrokePointsEvt, points))
+	if !ok {
+		t.Fatal("expected points to be encodable")
+	}
+
+	// Small deltas take one byte per coordinate
+	if want := 1 + 1 + 1 + 2*2 + 2*(len(points)-1); len(msg) != want {
+		t.Errorf("expected %d bytes, got %d", want, len(msg))
+	}
+}
+
+func TestFrame_EncodeParts(t *testing.T) {
+	f := newFrame(
+		event(ClearStrokesEvt, nil),
+		event(SetPlayersEvt, map[uuid.UUID]*player{}),
+		event(AddStrokePointEvt, []int{1, 2}),
+		event(AddStrokePointEvt, []int{3, 4}),
+		event(AddStrokePointEvt, "not a point"),
+	)
+
+	parts := f.encodeParts()
+	want := []bool{true, false, true, false}
+	if len(parts) != len(want) {
+		t.Fatalf("expected %d parts, got %d", len(want), len(parts))
+	}
+	for i, part := range parts {
+		if part.binary != want[i] {
+			t.Errorf("expected part %d binary=%v", i, want[i])
+		}
+	}
+}
+
+func TestDrawingState_AcceptsBinaryCommands(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer},
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+	}
+
+	msg, _ := appendBinaryEvent(nil, event(AddStrokeEvt, Stroke{Color: "#000000", Width: 5, Type: "brush"}))
+	msg, _ = appendBinaryEvent(msg, event(AddStrokePointEvt, []int{100, 100}))
+	commands, err := decodeBinaryCommands(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, cmd := range commands {
+		cmd.Player = drawer
+		if err := state.HandleCommand(room, cmd); err != nil {
+			t.Fatalf("unexpected error handling %s: %v", cmd.Type, err)
+		}
+	}
+
+	if len(state.strokes) != 1 || !reflect.DeepEqual(state.strokes[0].Points, [][]int{{100, 100}}) {
+		t.Errorf("expected binary commands to build the same strokes, got %+v", state.strokes)
+	}
+}
@@ -19,9 +19,6 @@ const (
 
 	// Send pings to client with this period. Must be less than pongWait.
 	pingPeriod = (pongWait * 9) / 10
-
-	// Maximum message size allowed from client.
-	maxMessageSize = 512
 )
 
 // client represents a connection between the server and a client.
@@ -35,17 +32,29 @@ type client struct {
 	player  *player
 	conn    *websocket.Conn
 	limiter *rate.Limiter
-	send    chan []*Event
+	outbox  *outbox
 	cancel  context.CancelCauseFunc
+
+	// Whether the client negotiated the binary protocol for canvas messages
+	binary bool
+
+	// Closed when the room's goroutine shuts down
+	roomDone <-chan struct{}
 }
 
 func NewClient(conn *websocket.Conn, room *room, player *player) *client {
+	cfg := defaultConfig
+	if room != nil {
+		cfg = room.config()
+	}
+
 	return &client{
 		room:    room,
 		player:  player,
 		conn:    conn,
-		limiter: rate.NewLimiter(2, 4),
-		send:    make(chan []*Event, 256),
+		limiter: rate.NewLimiter(rate.Limit(cfg.Clients.RateLimit), cfg.Clients.RateBurst),
+		outbox:  newOutbox(),
+		binary:  conn != nil && conn.Subprotocol() == BinarySubprotocol,
 	}
 }
 
@@ -54,6 +63,7 @@ func NewClient(conn *websocket.Conn, room *room, player *player) *client {
 func (c *client) run(roomCtx context.Context) {
 	ctx, cancel := context.WithCancelCause(roomCtx)
 	c.cancel = cancel
+	c.roomDone = roomCtx.Done()
 
 	// Goroutines use this channel to tell us when they're ready
 	ready := make(chan bool, 2)
@@ -86,25 +96,32 @@ func (c *client) read(ctx context.Context, ready chan<- bool) {
 	// Tell the caller we're ready
 	ready <- true
 
+	clientsConnected.inc()
+
 	// This runs when the routine exits
 	defer func() {
 		c.conn.Close()
+		clientsConnected.dec()
 		slog.Debug("read routine exited", "playerId", c.player.ID)
 	}()
 
 	// Configure the websocket connection settings
-	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadLimit(c.room.config().Clients.MaxMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error { c.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 
 	for {
 		select {
 		case <-ctx.Done():
-			c.room.disconnect <- c.player
+			// If the room has shut down there is nobody left to tell
+			select {
+			case c.room.disconnect <- &disconnection{client: c, cause: context.Cause(ctx)}:
+			case <-c.roomDone:
+			}
 			slog.Debug("read routine cancelled", "playerId", c.player.ID, "cause", context.Cause(ctx))
 			return
 		default:
-			_, msgBytes, err := c.conn.ReadMessage()
+			msgType, msgBytes, err := c.conn.ReadMessage()
 			if err != nil {
 				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 					slog.Warn("unexpected close error", "playerId", c.player.ID, "error", err)
@@ -114,30 +131,52 @@ func (c *client) read(ctx context.Context, ready chan<- bool) {
 			}
 
 			// Parse the message into a usable data structure
-			cmd, err := decodeCommand(msgBytes)
+			commands, err := c.decodeMessage(msgType, msgBytes)
 			if err != nil {
 				slog.Warn("Error un-marshalling message", "playerId", c.player.ID, "error", err)
 				c.cancel(err)
 				break
 			}
 
-			// Make sure the client has not exceeded their rate limit
-			// Ignore rate limit for stroke commands if the player is drawing (drawing has a lot of events)
-			if c.limiter.Allow() || (c.player.GameRole == GameRoleDrawing && cmd.isStrokeCommand()) {
-				// Add the player to the command so the room knows who sent it
-				cmd.Player = c.player
-
-				// Send the command to the room to be processed
-				c.room.command <- cmd
-			} else {
-				// The client has exceeded their rate limit, do nothing with the message
-				slog.Debug("dropped event", "playerId", c.player.ID)
+			for _, cmd := range commands {
+				// Make sure the client has not exceeded their rate limit
+				// Ignore rate limit for stroke commands if the player is drawing (drawing has a lot of events)
+				if c.limiter.Allow() || (c.player.GameRole == GameRoleDrawing && cmd.isStrokeCommand()) {
+					// Add the player to the command so the room knows who sent it
+					cmd.Player = c.player
+
+					// Send the command to the room to be processed
+					c.room.command <- cmd
+				} else {
+					// The client has exceeded their rate limit, do nothing with the message
+					commandsDropped.inc()
+					slog.Debug("dropped event", "playerId", c.player.ID)
+				}
 			}
 
 		}
 	}
 }
 
+// Decodes a message from the client into commands.
+//
+// Text messages are always JSON. Binary messages are only accepted from
+// clients that negotiated the binary protocol.
+func (c *client) decodeMessage(msgType int, msg []byte) ([]*Command, error) {
+	if msgType == websocket.BinaryMessage {
+		if !c.binary {
+			return nil, ErrInvalidBinaryMessage
+		}
+		return decodeBinaryCommands(msg)
+	}
+
+	cmd, err := decodeCommand(msg)
+	if err != nil {
+		return nil, err
+	}
+	return []*Command{cmd}, nil
+}
+
 // Writes messages to the client's websocket connection.
 //
 // A goroutine running write is started for each client connection.
@@ -174,35 +213,26 @@ func (c *client) write(ctx context.Context, ready chan<- bool) {
 
 			slog.Debug("write routine cancelled", "player", c.player.ID, "cause", context.Cause(ctx))
 			return
-		case events, ok := <-c.send:
-			// Set the write deadline
-			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-
-			// If the channel is closed, cancel the client connection
-			if !ok {
-				slog.Debug("write routine channel closed", "playerId", c.player.ID)
-				c.cancel(nil)
+		case <-c.outbox.ready:
+			// Take everything queued since the last write and send it as one message
+			frames := c.outbox.drain()
+			if len(frames) == 0 {
 				break
 			}
+			sendQueueDepth.observe(float64(len(frames)))
 
-			// Get the next writer for the connection
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				slog.Warn("error getting next writer", "playerId", c.player.ID, "error", err)
-				c.cancel(err)
-				break
-			}
+			// Set the write deadline
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 
-			// Encode the events and send them to the client
-			jsonBytes, err := encodeEvents(events)
-			if err != nil {
-				slog.Warn("error encoding events", "playerId", c.player.ID, "error", err)
-				break
+			// Write the frames using the protocol the client negotiated
+			write := writeFrames
+			if c.binary {
+				write = writeFramesBinary
 			}
-			w.Write(jsonBytes)
 
-			// If the writer fails to close, cancel the client connection
-			if err := w.Close(); err != nil {
+			// If the write fails, cancel the client connection
+			if err := write(c.conn, frames); err != nil {
+				slog.Warn("error writing frames", "playerId", c.player.ID, "error", err)
 				c.cancel(err)
 			}
 		case <-ticker.C:
@@ -2,7 +2,7 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/mitchellh/mapstructure"
@@ -12,45 +12,97 @@ import (
 // They are used to trigger actions on the server and update the state of the game.
 
 var (
-	ErrGameNotInitialized = errors.New("game is not initialized")
+	ErrGameNotInitialized = &CommandError{ErrCodeGameNotInitialized, "game is not initialized"}
 
-	ErrInvalidChatMessage = errors.New("invalid chat message")
+	ErrInvalidChatMessage = &CommandError{ErrCodeInvalidChatMessage, "invalid chat message"}
 
-	ErrNotInRoomScene    = errors.New("game must be in room scene to perform this action")
-	ErrNotInPickingScene = errors.New("game must be in picking scene to perform this  action")
-	ErrNotInDrawingScene = errors.New("game must be in drawing scene to perform this action")
+	ErrNotInRoomScene    = &CommandError{ErrCodeWrongPhase, "game must be in room scene to perform this action"}
+	ErrNotInPickingScene = &CommandError{ErrCodeWrongPhase, "game must be in picking scene to perform this  action"}
+	ErrNotInDrawingScene = &CommandError{ErrCodeWrongPhase, "game must be in drawing scene to perform this action"}
 
-	ErrNotEnoughPlayers     = errors.New("you need at least 2 players to start the game")
-	ErrNotEnoughCustomWords = errors.New("you need to provide at least 3 custom words in custom only mode")
-	ErrWordAlreadySelected  = errors.New("word already selected")
+	ErrNotEnoughPlayers     = &CommandError{ErrCodeNotEnoughPlayers, "you need at least 2 players to start the game"}
+	ErrNotEnoughCustomWords = &CommandError{ErrCodeNotEnoughCustomWords, "you need to provide at least 3 custom words in custom only mode"}
+	ErrWordAlreadySelected  = &CommandError{ErrCodeWordAlreadySelected, "word already selected"}
 
-	ErrInvalidCommand = errors.New("invalid or unhandled command")
+	ErrInvalidCommand = &CommandError{ErrCodeInvalidCommand, "invalid or unhandled command"}
+	ErrInvalidPayload = &CommandError{ErrCodeInvalidPayload, "invalid command payload"}
 )
 
 type CommandType string
 
 const (
-	AddStrokeCmd      CommandType = "canvas/addStroke"
-	AddStrokePointCmd CommandType = "canvas/addStrokePoint"
-	ClearStrokesCmd   CommandType = "canvas/clearStrokes"
-	UndoStrokeCmd     CommandType = "canvas/undoStroke"
+	AddStrokeCmd       CommandType = "canvas/addStroke"
+	AddStrokePointCmd  CommandType = "canvas/addStrokePoint"
+	AddStrokePointsCmd CommandType = "canvas/addStrokePoints"
+	ClearStrokesCmd    CommandType = "canvas/clearStrokes"
+	UndoStrokeCmd      CommandType = "canvas/undoStroke"
 
-	ChatMessageCmd CommandType = "room/newChatMessage"
-	SelectWordCmd  CommandType = "game/selectWord"
-	StartGameCmd   CommandType = "game/start"
+	ChatMessageCmd  CommandType = "room/newChatMessage"
+	SelectWordCmd   CommandType = "game/selectWord"
+	StartGameCmd    CommandType = "game/start"
+	VoteRematchCmd  CommandType = "game/voteRematch"
+	VoteSkipWordCmd CommandType = "game/voteSkipWord"
+
+	TelephoneSubmitCmd CommandType = "telephone/submit"
 
 	ChangeRoomSettingsCmd  CommandType = "room/changeRoomSettings"
+	PromoteSpectatorCmd    CommandType = "room/promoteSpectator"
+	AssignTeamCmd          CommandType = "room/assignTeam"
 	UpdatePlayerProfileCmd CommandType = "room/updatePlayerProfile"
 
+	KickPlayerCmd   CommandType = "room/kickPlayer"
+	BanPlayerCmd    CommandType = "room/banPlayer"
+	MutePlayerCmd   CommandType = "room/mutePlayer"
+	TransferHostCmd CommandType = "room/transferHost"
+
+	StartVoteKickCmd CommandType = "room/startVoteKick"
+	VoteKickCmd      CommandType = "room/voteKick"
+
 	PlayerLeftCmd   CommandType = "room/playerLeft"
 	PlayerJoinedCmd CommandType = "room/playerJoined"
 )
 
+// The command types clients can send. Command types come straight from
+// the client, so anything else is counted as "other" in the metrics
+// rather than taking up a label value.
+var clientCommandTypes = map[CommandType]bool{
+	AddStrokeCmd:           true,
+	AddStrokePointCmd:      true,
+	AddStrokePointsCmd:     true,
+	ClearStrokesCmd:        true,
+	UndoStrokeCmd:          true,
+	ChatMessageCmd:         true,
+	SelectWordCmd:          true,
+	StartGameCmd:           true,
+	VoteRematchCmd:         true,
+	VoteSkipWordCmd:        true,
+	TelephoneSubmitCmd:     true,
+	ChangeRoomSettingsCmd:  true,
+	PromoteSpectatorCmd:    true,
+	AssignTeamCmd:          true,
+	UpdatePlayerProfileCmd: true,
+	KickPlayerCmd:          true,
+	BanPlayerCmd:           true,
+	MutePlayerCmd:          true,
+	TransferHostCmd:        true,
+	StartVoteKickCmd:       true,
+	VoteKickCmd:            true,
+}
+
+// Returns the label to count a client command under in the metrics
+func commandMetricLabel(t CommandType) string {
+	if clientCommandTypes[t] {
+		return string(t)
+	}
+	return "other"
+}
+
 // Command represents an action sent from a client to the server.
 type Command struct {
-	Type    CommandType `json:"type"`
-	Payload interface{} `json:"payload"`
-	Player  *player     `json:"-"` // the player that sent the command
+	Type      CommandType `json:"type"`
+	Payload   interface{} `json:"payload"`
+	RequestID string      `json:"requestId,omitempty"` // optional, echoed back in the command result
+	Player    *player     `json:"-"`                   // the player that sent the command
 }
 
 // Decode a JSON byte slice into a command
@@ -70,7 +122,7 @@ func decodePayload[T any](payload interface{}) (T, error) {
 	err := mapstructure.Decode(payload, &target)
 	if err != nil {
 		slog.Debug("failed to decode payload", "error", err)
-		return target, err
+		return target, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
 	}
 	return target, nil
 }
@@ -79,5 +131,5 @@ func decodePayload[T any](payload interface{}) (T, error) {
 //
 // Used to determine if we should ignore the client rate limiter
 func (c *Command) isStrokeCommand() bool {
-	return c.Type == AddStrokePointCmd || c.Type == AddStrokeCmd || c.Type == UndoStrokeCmd || c.Type == ClearStrokesCmd
+	return c.Type == AddStrokePointCmd || c.Type == AddStrokePointsCmd || c.Type == AddStrokeCmd || c.Type == UndoStrokeCmd || c.Type == ClearStrokesCmd
 }
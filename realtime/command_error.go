@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is a stable, machine-readable identifier for why a command failed.
+//
+// Clients use these to decide what to show the player, so existing codes should
+// never be renamed. The messages attached to them are for humans and can change.
+type ErrorCode string
+
+const (
+	ErrCodeUnknown        ErrorCode = "ErrUnknown"
+	ErrCodeInvalidCommand ErrorCode = "ErrInvalidCommand"
+	ErrCodeInvalidPayload ErrorCode = "ErrInvalidPayload"
+
+	// Permissions
+	ErrCodeWrongRoomRole ErrorCode = "ErrWrongRoomRole"
+	ErrCodeWrongGameRole ErrorCode = "ErrWrongGameRole"
+	ErrCodeNotDrawer     ErrorCode = "ErrNotDrawer"
+	ErrCodeNotYourTurn   ErrorCode = "ErrNotYourTurn"
+
+	// Game flow
+	ErrCodeGameNotInitialized    ErrorCode = "ErrGameNotInitialized"
+	ErrCodeWrongPhase            ErrorCode = "ErrWrongPhase"
+	ErrCodeNotEnoughPlayers      ErrorCode = "ErrNotEnoughPlayers"
+	ErrCodeNotEnoughCustomWords  ErrorCode = "ErrNotEnoughCustomWords"
+	ErrCodeNotEnoughRelayPlayers ErrorCode = "ErrNotEnoughRelayPlayers"
+	ErrCodeWordAlreadySelected   ErrorCode = "ErrWordAlreadySelected"
+	ErrCodeInvalidWord           ErrorCode = "ErrInvalidWord"
+	ErrCodeRoomFull              ErrorCode = "ErrRoomFull"
+	ErrCodeNotSpectator          ErrorCode = "ErrNotSpectator"
+
+	// Moderation
+	ErrCodePlayerNotFound ErrorCode = "ErrPlayerNotFound"
+	ErrCodeMuted          ErrorCode = "ErrMuted"
+
+	// Vote kicks
+	ErrCodeVoteKickInProgress ErrorCode = "ErrVoteKickInProgress"
+	ErrCodeVoteKickCooldown   ErrorCode = "ErrVoteKickCooldown"
+
+	// Teams
+	ErrCodeNotTeamGame          ErrorCode = "ErrNotTeamGame"
+	ErrCodeInvalidTeam          ErrorCode = "ErrInvalidTeam"
+	ErrCodeNotEnoughTeamPlayers ErrorCode = "ErrNotEnoughTeamPlayers"
+	ErrCodeStealNotOpen         ErrorCode = "ErrStealNotOpen"
+
+	// Telephone
+	ErrCodeAlreadySubmitted      ErrorCode = "ErrAlreadySubmitted"
+	ErrCodeInvalidTelephoneEntry ErrorCode = "ErrInvalidTelephoneEntry"
+
+	// Input validation
+	ErrCodeInvalidChatMessage ErrorCode = "ErrInvalidChatMessage"
+	ErrCodeInvalidSettings    ErrorCode = "ErrInvalidSettings"
+	ErrCodeInvalidProfile     ErrorCode = "ErrInvalidProfile"
+	ErrCodeInvalidStroke      ErrorCode = "ErrInvalidStroke"
+	ErrCodeCanvasFull         ErrorCode = "ErrCanvasFull"
+)
+
+// CommandError is an error that can be reported back to the player who sent a command.
+type CommandError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *CommandError) Error() string {
+	return e.Message
+}
+
+// Creates a command error with a formatted message.
+func commandErrorf(code ErrorCode, format string, args ...any) error {
+	return &CommandError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Returns the error code of the first command error in the chain.
+func errorCodeOf(err error) ErrorCode {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code
+	}
+	return ErrCodeUnknown
+}
+
+// CommandResult is sent back to the player who sent a command.
+//
+// Clients can attach a request ID to any command. Successful commands with a
+// request ID are acknowledged, and failed commands are always reported so the
+// client can show why an action didn't go through.
+type CommandResult struct {
+	RequestID string      `json:"requestId,omitempty"`
+	Command   CommandType `json:"command"`
+	Code      ErrorCode   `json:"code,omitempty"`
+	Message   string      `json:"message,omitempty"`
+}
+
+func NewCommandResult(cmd *Command, err error) CommandResult {
+	result := CommandResult{
+		RequestID: cmd.RequestID,
+		Command:   cmd.Type,
+	}
+	if err != nil {
+		result.Code = errorCodeOf(err)
+		result.Message = err.Error()
+	}
+	return result
+}
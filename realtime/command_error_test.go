@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{
+			name: "sentinel error",
+			err:  ErrNotEnoughPlayers,
+			want: ErrCodeNotEnoughPlayers,
+		},
+		{
+			name: "wrapped validation error",
+			err:  fmt.Errorf("invalid room settings: %w", validateRoomSettings(&RoomSettings{}, DefaultConfig().Rooms)),
+			want: ErrCodeInvalidSettings,
+		},
+		{
+			name: "payload decode error",
+			err: func() error {
+				_, err := decodePayload[Stroke]("not a stroke")
+				return err
+			}(),
+			want: ErrCodeInvalidPayload,
+		},
+		{
+			name: "plain error",
+			err:  fmt.Errorf("something went wrong"),
+			want: ErrCodeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCodeOf(tt.err); got != tt.want {
+				t.Errorf("errorCodeOf(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatch_RespondsToSender(t *testing.T) {
+	host := &player{ID: uuid.New(), RoomRole: RoomRoleHost}
+	host.client = NewClient(nil, nil, host)
+
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID: host,
+		},
+		Settings: RoomSettings{
+			WordBank: WordBankDefault,
+		},
+		currentState: NewWaitingState(),
+		scheduler:    NewGameScheduler(),
+	}
+
+	tests := []struct {
+		name      string
+		command   *Command
+		wantType  EventType
+		wantCode  ErrorCode
+		wantReply bool
+	}{
+		{
+			name:      "failed command is reported without a request ID",
+			command:   &Command{Type: StartGameCmd},
+			wantType:  CommandErrorEvt,
+			wantCode:  ErrCodeNotEnoughPlayers,
+			wantReply: true,
+		},
+		{
+			name:      "failed command echoes the request ID",
+			command:   &Command{Type: StartGameCmd, RequestID: "req-1"},
+			wantType:  CommandErrorEvt,
+			wantCode:  ErrCodeNotEnoughPlayers,
+			wantReply: true,
+		},
+		{
+			name:      "successful command is acknowledged with a request ID",
+			command:   &Command{Type: ChatMessageCmd, Payload: "hello", RequestID: "req-2"},
+			wantType:  CommandAckEvt,
+			wantReply: true,
+		},
+		{
+			name:      "successful command without a request ID gets no reply",
+			command:   &Command{Type: ChatMessageCmd, Payload: "hello"},
+			wantReply: false,
+		},
+		{
+			name:      "chat message with invalid payload",
+			command:   &Command{Type: ChatMessageCmd, Payload: 42},
+			wantType:  CommandErrorEvt,
+			wantCode:  ErrCodeInvalidChatMessage,
+			wantReply: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.command.Player = host
+			room.dispatch(tt.command)
+
+			// Find the command result among everything the host was sent
+			var result *CommandResult
+			for _, evt := range drainEvents(host.client) {
+				if evt.Type == CommandErrorEvt || evt.Type == CommandAckEvt {
+					if evt.Type != tt.wantType {
+						t.Errorf("expected %s, got %s", tt.wantType, evt.Type)
+					}
+					r := evt.Payload.(CommandResult)
+					result = &r
+				}
+			}
+
+			if !tt.wantReply {
+				if result != nil {
+					t.Errorf("expected no reply, got %+v", result)
+				}
+				return
+			}
+			if result == nil {
+				t.Fatal("expected a reply, got none")
+			}
+			if result.Code != tt.wantCode {
+				t.Errorf("expected code %s, got %s", tt.wantCode, result.Code)
+			}
+			if result.RequestID != tt.command.RequestID {
+				t.Errorf("expected request ID %q, got %q", tt.command.RequestID, result.RequestID)
+			}
+		})
+	}
+}
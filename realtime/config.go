@@ -0,0 +1,425 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds everything about the server that can be tuned without a rebuild.
+//
+// It starts from DefaultConfig, then the JSON file at CONFIG_FILE is applied
+// on top if there is one, then environment variables override single values
+// (see envOverrides). The result is validated once at startup and handed to
+// the room manager, rooms, clients and the websocket upgrader, nothing reads
+// it from a global.
+//
+// A redacted copy can be read at /debug/config when Server.DebugEndpoints is on.
+type Config struct {
+	Server   ServerConfig   `json:"server"`
+	Rooms    RoomsConfig    `json:"rooms"`
+	Game     GameConfig     `json:"game"`
+	Clients  ClientsConfig  `json:"clients"`
+	Sessions SessionsConfig `json:"sessions"`
+	IPLimits IPLimitConfig  `json:"ipLimits"`
+}
+
+type ServerConfig struct {
+	Host string `json:"host"`
+	Port string `json:"port"`
+
+	// Anything other than PRODUCTION allows websocket connections from any origin
+	Environment string `json:"environment"`
+
+	// Frontends allowed to connect in production, either exact origins
+	// or regular expressions for preview deployments
+	AllowedOrigins        []string `json:"allowedOrigins"`
+	AllowedOriginPatterns []string `json:"allowedOriginPatterns"`
+
+	// Serves the config at /debug/config
+	DebugEndpoints bool `json:"debugEndpoints"`
+}
+
+type RoomsConfig struct {
+	// Maximum number of rooms that can be open at once
+	MaxRooms int `json:"maxRooms"`
+
+	// How long a room can be idle before it gets cleaned up
+	RoomTimeout Duration `json:"roomTimeout"`
+
+	// How long a player can be idle before the room disconnects them
+	PlayerTimeout Duration `json:"playerTimeout"`
+
+	// Bounds for the room settings the host can pick
+	MaxPlayers     int `json:"maxPlayers"`
+	MinDrawingTime int `json:"minDrawingTime"` // seconds
+	MaxDrawingTime int `json:"maxDrawingTime"` // seconds
+	MinRounds      int `json:"minRounds"`
+	MaxRounds      int `json:"maxRounds"`
+
+	// Spectators don't count towards the player limit, they have their own
+	MaxSpectators int `json:"maxSpectators"`
+}
+
+type GameConfig struct {
+	// Duration allowed for players to pick a word
+	PickingPhaseDuration Duration `json:"pickingPhaseDuration"`
+
+	// Duration after drawing for score updates and displaying the word
+	PostDrawingPhaseDuration Duration `json:"postDrawingPhaseDuration"`
+
+	// How long the final standings are shown before the room goes back to the lobby
+	GameOverDuration Duration `json:"gameOverDuration"`
+
+	// Share of the players that has to vote for a rematch before it starts
+	RematchMajority float64 `json:"rematchMajority"`
+
+	// Share of the guessers that has to vote to skip the drawing before it's skipped
+	SkipWordMajority float64 `json:"skipWordMajority"`
+
+	// Share of the drawing time that has to pass in a team game before the
+	// other team can steal the drawing, ex. 0.5 for halfway through
+	TeamStealDelay float64 `json:"teamStealDelay"`
+
+	// How many players share each word in a relay game
+	RelayDrawers int `json:"relayDrawers"`
+
+	// How long players have to write a prompt or description in a telephone
+	// game, drawing steps use the room's drawing time
+	TelephoneWritingDuration Duration `json:"telephoneWritingDuration"`
+
+	// How long each entry is shown in the reveal at the end of a telephone game
+	TelephoneRevealInterval Duration `json:"telephoneRevealInterval"`
+
+	// How often stroke points from the drawer are sent to the guessers
+	StrokeFlushInterval Duration `json:"strokeFlushInterval"`
+
+	// How long players have to vote in a vote kick
+	VoteKickDuration Duration `json:"voteKickDuration"`
+
+	// How long a player has to wait before starting another vote kick,
+	// and before the same player can be voted on again
+	VoteKickCooldown Duration `json:"voteKickCooldown"`
+
+	// How long a player removed by a vote has to wait before they can rejoin
+	VoteKickRejoinCooldown Duration `json:"voteKickRejoinCooldown"`
+}
+
+type ClientsConfig struct {
+	// Commands per second a client can send, drawing isn't limited
+	RateLimit float64 `json:"rateLimit"`
+	RateBurst int     `json:"rateBurst"`
+
+	// Maximum size of a message from a client in bytes
+	MaxMessageSize int64 `json:"maxMessageSize"`
+}
+
+type SessionsConfig struct {
+	// How long a disconnected player keeps their slot, 0 turns resuming off
+	ResumeGracePeriod Duration `json:"resumeGracePeriod"`
+
+	// Key used to sign resume tokens. Rooms only live in memory, so a random
+	// key per process is enough unless the server is run behind a load
+	// balancer with sticky sessions.
+	ResumeSecret string `json:"resumeSecret,omitempty"`
+
+	// The key actually used, ResumeSecret or a random one
+	resumeKey []byte
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Host:           "0.0.0.0",
+			Port:           "8080",
+			AllowedOrigins: []string{"https://sketchwithfriends.com"},
+			AllowedOriginPatterns: []string{
+				`^https?:\/\/([\w-]+\.)*sketch-with-friends\.pages\.dev$`,
+			},
+		},
+		Rooms: RoomsConfig{
+			MaxRooms:       20,
+			RoomTimeout:    Duration{20 * time.Minute},
+			PlayerTimeout:  Duration{15 * time.Minute},
+			MaxPlayers:     10,
+			MinDrawingTime: 15,
+			MaxDrawingTime: 240,
+			MinRounds:      1,
+			MaxRounds:      10,
+			MaxSpectators:  20,
+		},
+		Game: GameConfig{
+			PickingPhaseDuration:     Duration{15 * time.Second},
+			PostDrawingPhaseDuration: Duration{5 * time.Second},
+			GameOverDuration:         Duration{15 * time.Second},
+			RematchMajority:          0.5,
+			SkipWordMajority:         0.5,
+			TeamStealDelay:           0.5,
+			RelayDrawers:             2,
+			TelephoneWritingDuration: Duration{30 * time.Second},
+			TelephoneRevealInterval:  Duration{3 * time.Second},
+			StrokeFlushInterval:      Duration{40 * time.Millisecond},
+			VoteKickDuration:         Duration{30 * time.Second},
+			VoteKickCooldown:         Duration{2 * time.Minute},
+			VoteKickRejoinCooldown:   Duration{10 * time.Minute},
+		},
+		Clients: ClientsConfig{
+			RateLimit:      2,
+			RateBurst:      4,
+			MaxMessageSize: 512,
+		},
+		Sessions: SessionsConfig{
+			ResumeGracePeriod: Duration{30 * time.Second},
+		},
+		IPLimits: DefaultIPLimitConfig(),
+	}
+}
+
+// Used by rooms and clients that weren't given a config, like the ones built in tests
+var defaultConfig = mustValidate(DefaultConfig())
+
+func mustValidate(cfg *Config) *Config {
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// Loads the config file at path, if there is one, applies the environment
+// overrides and validates the result.
+func LoadConfig(path string, getenv func(string) string) (*Config, error) {
+	cfg := DefaultConfig()
+
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config file: %w", err)
+		}
+		defer f.Close()
+
+		// Typos in the file shouldn't silently leave a default in place
+		dec := json.NewDecoder(f)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	}
+
+	for _, o := range envOverrides {
+		value := getenv(o.name)
+		if value == "" {
+			continue
+		}
+		if err := setFromEnv(o.field(cfg), value); err != nil {
+			return nil, fmt.Errorf("invalid %s env: %w", o.name, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// An environment variable that overrides a single config value
+type envOverride struct {
+	name  string
+	field func(cfg *Config) any
+}
+
+var envOverrides = []envOverride{
+	{"HOST", func(c *Config) any { return &c.Server.Host }},
+	{"PORT", func(c *Config) any { return &c.Server.Port }},
+	{"ENVIRONMENT", func(c *Config) any { return &c.Server.Environment }},
+	{"ALLOWED_ORIGINS", func(c *Config) any { return &c.Server.AllowedOrigins }},
+	{"DEBUG_ENDPOINTS", func(c *Config) any { return &c.Server.DebugEndpoints }},
+
+	{"MAX_ROOMS", func(c *Config) any { return &c.Rooms.MaxRooms }},
+	{"ROOM_TIMEOUT", func(c *Config) any { return &c.Rooms.RoomTimeout }},
+	{"PLAYER_TIMEOUT", func(c *Config) any { return &c.Rooms.PlayerTimeout }},
+	{"MAX_PLAYERS", func(c *Config) any { return &c.Rooms.MaxPlayers }},
+	{"MAX_DRAWING_TIME", func(c *Config) any { return &c.Rooms.MaxDrawingTime }},
+	{"MAX_ROUNDS", func(c *Config) any { return &c.Rooms.MaxRounds }},
+	{"MAX_SPECTATORS", func(c *Config) any { return &c.Rooms.MaxSpectators }},
+
+	{"PICKING_PHASE_DURATION", func(c *Config) any { return &c.Game.PickingPhaseDuration }},
+	{"GAME_OVER_DURATION", func(c *Config) any { return &c.Game.GameOverDuration }},
+	{"REMATCH_MAJORITY", func(c *Config) any { return &c.Game.RematchMajority }},
+	{"SKIP_WORD_MAJORITY", func(c *Config) any { return &c.Game.SkipWordMajority }},
+	{"TEAM_STEAL_DELAY", func(c *Config) any { return &c.Game.TeamStealDelay }},
+	{"RELAY_DRAWERS", func(c *Config) any { return &c.Game.RelayDrawers }},
+	{"TELEPHONE_WRITING_DURATION", func(c *Config) any { return &c.Game.TelephoneWritingDuration }},
+	{"TELEPHONE_REVEAL_INTERVAL", func(c *Config) any { return &c.Game.TelephoneRevealInterval }},
+	{"STROKE_FLUSH_INTERVAL", func(c *Config) any { return &c.Game.StrokeFlushInterval }},
+
+	{"CLIENT_RATE_LIMIT", func(c *Config) any { return &c.Clients.RateLimit }},
+	{"CLIENT_RATE_BURST", func(c *Config) any { return &c.Clients.RateBurst }},
+	{"MAX_MESSAGE_SIZE", func(c *Config) any { return &c.Clients.MaxMessageSize }},
+
+	{"RESUME_GRACE_PERIOD", func(c *Config) any { return &c.Sessions.ResumeGracePeriod }},
+	{"RESUME_SECRET", func(c *Config) any { return &c.Sessions.ResumeSecret }},
+
+	{"MAX_CONNECTIONS_PER_IP", func(c *Config) any { return &c.IPLimits.MaxConnectionsPerIP }},
+	{"MAX_ROOM_CREATIONS_PER_IP", func(c *Config) any { return &c.IPLimits.MaxRoomCreationsPerIP }},
+	{"ROOM_CREATION_WINDOW", func(c *Config) any { return &c.IPLimits.RoomCreationWindow }},
+	{"TRUSTED_PROXIES", func(c *Config) any { return &c.IPLimits.TrustedProxies }},
+}
+
+// Parses an environment variable into the config field it overrides.
+// Lists are comma separated.
+func setFromEnv(field any, value string) error {
+	switch f := field.(type) {
+	case *string:
+		*f = value
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*f = b
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*f = n
+	case *int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*f = n
+	case *float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*f = n
+	case *Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		f.Duration = d
+	case *[]string:
+		list := make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*f = list
+	case *[]netip.Prefix:
+		proxies, err := ParseTrustedProxies(value)
+		if err != nil {
+			return err
+		}
+		*f = proxies
+	default:
+		return fmt.Errorf("unsupported config field type %T", field)
+	}
+	return nil
+}
+
+// Checks every value is usable, returning all the problems at once so
+// a bad deploy doesn't have to be fixed one value at a time.
+func (cfg *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(cfg.Server.Port != "", "server.port is required")
+	for _, pattern := range cfg.Server.AllowedOriginPatterns {
+		_, err := regexp.Compile(pattern)
+		check(err == nil, "server.allowedOriginPatterns: %q is not a valid pattern: %v", pattern, err)
+	}
+
+	r := cfg.Rooms
+	check(r.MaxRooms > 0, "rooms.maxRooms must be positive")
+	check(r.RoomTimeout.Duration > 0, "rooms.roomTimeout must be positive")
+	check(r.PlayerTimeout.Duration > 0, "rooms.playerTimeout must be positive")
+	check(r.MaxPlayers >= MIN_PLAYERS, "rooms.maxPlayers must be at least %d", MIN_PLAYERS)
+	check(r.MinDrawingTime > 0, "rooms.minDrawingTime must be positive")
+	check(r.MaxDrawingTime >= r.MinDrawingTime, "rooms.maxDrawingTime can't be less than rooms.minDrawingTime")
+	check(r.MinRounds > 0, "rooms.minRounds must be positive")
+	check(r.MaxRounds >= r.MinRounds, "rooms.maxRounds can't be less than rooms.minRounds")
+	check(r.MaxSpectators >= 0, "rooms.maxSpectators can't be negative")
+
+	g := cfg.Game
+	check(g.PickingPhaseDuration.Duration > 0, "game.pickingPhaseDuration must be positive")
+	check(g.PostDrawingPhaseDuration.Duration > 0, "game.postDrawingPhaseDuration must be positive")
+	check(g.GameOverDuration.Duration >= 0, "game.gameOverDuration can't be negative")
+	check(g.RematchMajority >= 0 && g.RematchMajority < 1, "game.rematchMajority must be at least 0 and less than 1")
+	check(g.SkipWordMajority >= 0 && g.SkipWordMajority < 1, "game.skipWordMajority must be at least 0 and less than 1")
+	check(g.TeamStealDelay >= 0 && g.TeamStealDelay <= 1, "game.teamStealDelay must be between 0 and 1")
+	check(g.RelayDrawers >= 2, "game.relayDrawers must be at least 2")
+	check(g.TelephoneWritingDuration.Duration > 0, "game.telephoneWritingDuration must be positive")
+	check(g.TelephoneRevealInterval.Duration > 0, "game.telephoneRevealInterval must be positive")
+	check(g.StrokeFlushInterval.Duration > 0, "game.strokeFlushInterval must be positive")
+	check(g.VoteKickDuration.Duration > 0, "game.voteKickDuration must be positive")
+	check(g.VoteKickCooldown.Duration >= 0, "game.voteKickCooldown can't be negative")
+	check(g.VoteKickRejoinCooldown.Duration >= 0, "game.voteKickRejoinCooldown can't be negative")
+
+	c := cfg.Clients
+	check(c.RateLimit > 0, "clients.rateLimit must be positive")
+	check(c.RateBurst > 0, "clients.rateBurst must be positive")
+	check(c.MaxMessageSize > 0, "clients.maxMessageSize must be positive")
+
+	check(cfg.Sessions.ResumeGracePeriod.Duration >= 0, "sessions.resumeGracePeriod can't be negative")
+
+	l := cfg.IPLimits
+	check(l.MaxConnectionsPerIP >= 0, "ipLimits.maxConnectionsPerIP can't be negative")
+	check(l.MaxRoomCreationsPerIP >= 0, "ipLimits.maxRoomCreationsPerIP can't be negative")
+	check(l.MaxRoomCreationsPerIP == 0 || l.RoomCreationWindow.Duration > 0, "ipLimits.roomCreationWindow must be positive")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+
+	if cfg.Sessions.ResumeSecret != "" {
+		cfg.Sessions.resumeKey = []byte(cfg.Sessions.ResumeSecret)
+	} else if cfg.Sessions.resumeKey == nil {
+		cfg.Sessions.resumeKey = randomSecret()
+	}
+	return nil
+}
+
+// Returns a copy that is safe to show, without the resume secret
+func (cfg *Config) redacted() Config {
+	c := *cfg
+	if c.Sessions.ResumeSecret != "" {
+		c.Sessions.ResumeSecret = "[redacted]"
+	}
+	return c
+}
+
+// Duration is a time.Duration written as a string in the config file, ex. "90s"
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"90s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	file := `{
+		"rooms": {"maxRooms": 50, "roomTimeout": "30m"},
+		"clients": {"rateLimit": 3}
+	}`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"MAX_ROOMS":       "75",
+		"ALLOWED_ORIGINS": "https://a.example, https://b.example",
+		"RESUME_SECRET":   "shh",
+	}
+	cfg, err := LoadConfig(path, func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Rooms.MaxRooms != 75 {
+		t.Errorf("expected the env to override the file, got maxRooms %d", cfg.Rooms.MaxRooms)
+	}
+	if cfg.Rooms.RoomTimeout.Duration != 30*time.Minute {
+		t.Errorf("expected roomTimeout from the file, got %v", cfg.Rooms.RoomTimeout)
+	}
+	if cfg.Clients.RateLimit != 3 {
+		t.Errorf("expected rateLimit from the file, got %v", cfg.Clients.RateLimit)
+	}
+	if cfg.Clients.RateBurst != DefaultConfig().Clients.RateBurst {
+		t.Errorf("expected values missing from the file to keep their defaults, got rateBurst %d", cfg.Clients.RateBurst)
+	}
+	if got := strings.Join(cfg.Server.AllowedOrigins, " "); got != "https://a.example https://b.example" {
+		t.Errorf("expected origins from the env, got %q", got)
+	}
+	if string(cfg.Sessions.resumeKey) != "shh" {
+		t.Error("expected resume tokens to be signed with the configured secret")
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name string
+		path string
+		env  map[string]string
+	}{
+		{name: "missing file", path: filepath.Join(dir, "nope.json")},
+		{name: "unknown field", path: write("typo.json", `{"rooms": {"maxRoom": 5}}`)},
+		{name: "duration without units", path: write("duration.json", `{"rooms": {"roomTimeout": 30}}`)},
+		{name: "env that doesn't parse", env: map[string]string{"MAX_ROOMS": "lots"}},
+		{name: "env that doesn't validate", env: map[string]string{"MAX_ROOMS": "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(tt.path, func(name string) string { return tt.env[name] })
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{name: "defaults are valid", modify: func(cfg *Config) {}},
+		{name: "no rooms", modify: func(cfg *Config) { cfg.Rooms.MaxRooms = 0 }, wantErr: "rooms.maxRooms"},
+		{name: "max players below the minimum", modify: func(cfg *Config) { cfg.Rooms.MaxPlayers = 1 }, wantErr: "rooms.maxPlayers"},
+		{name: "drawing time bounds swapped", modify: func(cfg *Config) { cfg.Rooms.MaxDrawingTime = 10 }, wantErr: "rooms.maxDrawingTime"},
+		{name: "majority of everyone", modify: func(cfg *Config) { cfg.Game.RematchMajority = 1 }, wantErr: "game.rematchMajority"},
+		{name: "no commands allowed", modify: func(cfg *Config) { cfg.Clients.RateLimit = 0 }, wantErr: "clients.rateLimit"},
+		{name: "bad origin pattern", modify: func(cfg *Config) { cfg.Server.AllowedOriginPatterns = []string{"("} }, wantErr: "server.allowedOriginPatterns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error about %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Every problem is reported at once
+	cfg := DefaultConfig()
+	cfg.Rooms.MaxRooms = 0
+	cfg.Clients.RateBurst = 0
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "rooms.maxRooms") || !strings.Contains(err.Error(), "clients.rateBurst") {
+		t.Errorf("expected both problems to be reported, got %v", err)
+	}
+}
+
+func TestDebugConfig_RedactsSecrets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Sessions.ResumeSecret = "shh"
+	cfg.Server.DebugEndpoints = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := *NewServer(NewRoomManager(cfg), cfg)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, w.Code)
+	}
+	if strings.Contains(w.Body.String(), "shh") {
+		t.Error("expected the resume secret to be redacted")
+	}
+
+	var served Config
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil {
+		t.Fatalf("expected the served config to parse, got %v", err)
+	}
+	if served.Rooms.RoomTimeout != cfg.Rooms.RoomTimeout {
+		t.Errorf("expected roomTimeout %v, got %v", cfg.Rooms.RoomTimeout, served.Rooms.RoomTimeout)
+	}
+
+	// It's not there unless it's turned on
+	cfg.Server.DebugEndpoints = false
+	handler = *NewServer(NewRoomManager(cfg), cfg)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+	if strings.Contains(w.Body.String(), "roomTimeout") {
+		t.Error("expected the debug endpoint to be off by default")
+	}
+}
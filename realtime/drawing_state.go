@@ -1,7 +1,6 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
@@ -17,33 +16,67 @@ const (
 )
 
 var (
-	ErrOnlyDrawerCanAddStrokePoints = errors.New("only the drawer can add stroke points")
-	ErrOnlyDrawerCanAddStrokes      = errors.New("only the drawer can add strokes")
-	ErrOnlyDrawerCanClearStrokes    = errors.New("only the drawer can clear strokes")
-	ErrOnlyDrawerCanUndoStroke      = errors.New("only the drawer can undo strokes")
+	ErrOnlyDrawerCanAddStrokePoints = &CommandError{ErrCodeNotDrawer, "only the drawer can add stroke points"}
+	ErrOnlyDrawerCanAddStrokes      = &CommandError{ErrCodeNotDrawer, "only the drawer can add strokes"}
+	ErrOnlyDrawerCanClearStrokes    = &CommandError{ErrCodeNotDrawer, "only the drawer can clear strokes"}
+	ErrOnlyDrawerCanUndoStroke      = &CommandError{ErrCodeNotDrawer, "only the drawer can undo strokes"}
+	ErrRoundOver                    = &CommandError{ErrCodeWrongPhase, "round is over"}
+	ErrTooManyStrokes               = &CommandError{ErrCodeCanvasFull, "too many strokes this round"}
+	ErrTooManyStrokePoints          = &CommandError{ErrCodeCanvasFull, "too many stroke points this round"}
+	ErrSittingOut                   = &CommandError{ErrCodeWrongGameRole, "you're sitting out until the next game"}
 )
 
 type Stroke struct {
 	Points [][]int `json:"points"`
 	Color  string  `json:"color"` // hex color
 	Width  int     `json:"width"`
-	Type   string  `json:"type,omitempty"` // "brush" or "fill"
+	Type   string  `json:"type,omitempty"` // "brush", "eraser" or "fill"
 }
 
 const (
 	// Time to wait after the drawing phase ends before transitioning to the post-drawing phase
 	// Used to allow players to see the correct word and scoreboard for a short period of time
 	EndOfDrawingTimeDelay = 3 * time.Second
+
+	// Limits on how much the drawer can add to the canvas in a round.
+	// Undoing or clearing doesn't give any of it back, these are mostly
+	// here to bound what we store and replay to players who join late.
+	MAX_STROKES_PER_ROUND       = 1000
+	MAX_STROKE_POINTS_PER_ROUND = 25000
 )
 
 type DrawingState struct {
-	currentWord Word
-	hintedWord  string
-	strokes     []Stroke
+	currentWord   Word
+	hintedWord    string
+	hintsRevealed int
+	strokes       []Stroke
+
+	// Stroke points already added to strokes but not yet sent to the guessers
+	pendingPoints [][]int
+
+	// Number of strokes and points added this round, see MAX_STROKES_PER_ROUND
+	strokeCount int
+	pointCount  int
+
+	// Whether the drawer's last stroke was rejected. The points that follow
+	// belong to it, so they're dropped until a stroke is accepted again.
+	strokeRejected bool
 
 	endsAt time.Time
 
+	// When the other team can start guessing, only set in team games
+	stealAt time.Time
+
+	// Whose turn it is to draw and until when, only set in relay games
+	turnDrawer   *player
+	turnEndsAt   time.Time
+	turnDuration time.Duration
+
 	pointsAwarded map[uuid.UUID]int
+
+	// Guessers who voted to skip the drawing, and whether it was skipped
+	skipVotes map[uuid.UUID]bool
+	skipped   bool
 }
 
 func NewDrawingState(word Word) RoomState {
@@ -63,18 +96,38 @@ func NewDrawingState(word Word) RoomState {
 		strokes:       make([]Stroke, 0),
 		hintedWord:    string(hintRunes),
 		pointsAwarded: make(map[uuid.UUID]int),
+		skipVotes:     make(map[uuid.UUID]bool),
 	}
 }
 
 func (state *DrawingState) Enter(room *room) {
 	state.endsAt = time.Now().Add(time.Second * time.Duration(room.Settings.DrawingTimeAllowed))
 
+	// Only the drawer who picked the word has the drawing role until now
+	for _, drawer := range room.currentDrawers {
+		drawer.GameRole = GameRoleDrawing
+		drawer.wordsDrawn = append(drawer.wordsDrawn, state.currentWord.Value)
+	}
+
 	room.broadcast(GameRoleGuessing,
 		event(SetSelectedWordEvt, NewWord(state.hintedWord, state.currentWord.Difficulty)),
 	)
+	room.broadcast(GameRoleDrawing,
+		event(SetSelectedWordEvt, state.currentWord),
+	)
 
 	slog.Debug("Entering drawing state", "endsAt", state.endsAt, "len(pointsAwarded)", len(state.pointsAwarded))
 
+	// In team games the other team can steal the drawing partway through
+	if room.isTeamGame() {
+		stealDelay := float64(time.Until(state.endsAt)) * room.config().Game.TeamStealDelay
+		state.stealAt = time.Now().Add(time.Duration(stealDelay))
+		room.scheduler.addEvent(ScheduledStealOpen, state.stealAt, func() {
+			room.scheduler.cancelEvent(ScheduledStealOpen)
+			room.SendSystemMessage("The other team can steal the drawing now!")
+		})
+	}
+
 	// If the game mode is not no hints, we start the hint routine
 	if room.Settings.GameMode != GameModeNoHints {
 		// Will apply up to 60% of the word length as hints
@@ -88,7 +141,7 @@ func (state *DrawingState) Enter(room *room) {
 			state.applyHint()
 			for _, p := range room.Players {
 				// Only send the hint to players who haven't guessed correctly yet
-				if state.pointsAwarded[p.ID] == 0 && p.ID != room.currentDrawer.ID {
+				if state.pointsAwarded[p.ID] == 0 && !room.isDrawer(p.ID) {
 					p.Send(event(SetSelectedWordEvt, NewWord(state.hintedWord, state.currentWord.Difficulty)))
 				}
 			}
@@ -97,10 +150,22 @@ func (state *DrawingState) Enter(room *room) {
 
 	// Inform players of the phase change
 	room.broadcast(GameRoleAny,
+		event(SetPlayersEvt, room.Players),
+		event(SetSkipVotesEvt, state.skipVoters()),
 		event(SetCurrentStateEvt, Drawing),
 		event(SetTimerEvt, state.endsAt.UTC()),
 	)
 
+	// In relay games the drawers take turns, each gets an equal slice of the time
+	if len(room.currentDrawers) > 1 {
+		state.turnDuration = time.Until(state.endsAt) / time.Duration(len(room.currentDrawers))
+		state.startTurn(room, room.leadDrawer())
+	} else {
+		room.broadcast(GameRoleAny,
+			event(SetRelayTurnEvt, nil),
+		)
+	}
+
 	// Schedule the round end event
 	room.scheduler.addEvent(ScheduledDrawingPhaseEnd, state.endsAt, func() {
 		state.handleDrawingPhaseEnd(room)
@@ -109,8 +174,11 @@ func (state *DrawingState) Enter(room *room) {
 
 func (state *DrawingState) Exit(room *room) {
 	room.scheduler.clearEvents()
+	state.flush(room)
 
-	room.currentDrawer.GameRole = GameRoleGuessing
+	for _, drawer := range room.currentDrawers {
+		drawer.GameRole = GameRoleGuessing
+	}
 	room.broadcast(GameRoleGuessing, event(SetPlayersEvt, room.Players))
 
 	room.setState(NewPostDrawingState(state.pointsAwarded))
@@ -120,6 +188,8 @@ func (state *DrawingState) HandleCommand(room *room, cmd *Command) error {
 	switch cmd.Type {
 	case AddStrokePointCmd:
 		return state.handleStrokePoint(room, cmd)
+	case AddStrokePointsCmd:
+		return state.handleStrokePoints(room, cmd)
 	case ChatMessageCmd:
 		return state.handleChatMessage(room, cmd)
 	case AddStrokeCmd:
@@ -132,6 +202,8 @@ func (state *DrawingState) HandleCommand(room *room, cmd *Command) error {
 		return state.handlePlayerLeft(room, cmd)
 	case PlayerJoinedCmd:
 		return state.handlePlayerJoined(room, cmd)
+	case VoteSkipWordCmd:
+		return state.handleSkipVote(room, cmd)
 	default:
 		slog.Error("Invalid action for current state", "action", cmd.Type)
 		return ErrInvalidEvent
@@ -141,7 +213,12 @@ func (state *DrawingState) HandleCommand(room *room, cmd *Command) error {
 // Handles the end of the drawing phase
 func (state *DrawingState) handleDrawingPhaseEnd(room *room) {
 	room.scheduler.clearEvents()
-	state.updateStreaks(room)
+	state.flush(room)
+
+	// Skipped drawings don't count towards anyone's streak
+	if !state.skipped {
+		state.updateStreaks(room)
+	}
 
 	// Send drawing phase summary
 	room.SendSystemMessage(state.drawingPhaseSummary(room))
@@ -172,6 +249,61 @@ func (state *DrawingState) isDrawingPhaseOver() bool {
 	return time.Now().After(state.endsAt)
 }
 
+// Checks if a correct guess from the player scores right now.
+// In team games only the drawer's teammates can score until the steal opens.
+func (state *DrawingState) canScore(room *room, p *player) bool {
+	if !room.isTeamGame() || p.Team == room.leadDrawer().Team {
+		return true
+	}
+	return !time.Now().Before(state.stealAt)
+}
+
+// Checks if the player can draw right now. notDrawer is returned for players
+// who aren't drawing the word, in relay games drawers also have to wait their turn.
+func (state *DrawingState) canDraw(room *room, p *player, notDrawer error) error {
+	if !room.isDrawer(p.ID) {
+		return notDrawer
+	}
+	if p != state.activeDrawer(room) {
+		return ErrNotYourTurn
+	}
+	return nil
+}
+
+// Returns the drawer whose turn it is, the only drawer outside of relay games
+func (state *DrawingState) activeDrawer(room *room) *player {
+	if state.turnDrawer != nil {
+		return state.turnDrawer
+	}
+	return room.leadDrawer()
+}
+
+// Returns the number of guessers who got the word, not counting
+// the points the drawers got for it
+func (state *DrawingState) correctGuessers(room *room) int {
+	count := 0
+	for id := range state.pointsAwarded {
+		if !room.isDrawer(id) {
+			count++
+		}
+	}
+	return count
+}
+
+// Returns the guesser's and drawer's points for a correct guess made
+// right now, using the room's scorer
+func (state *DrawingState) guessPoints(room *room, guesser *player) (guesserPoints, drawerPoints int) {
+	return scorerFor(room.Settings.ScoringMode).GuessPoints(GuessContext{
+		Guessers:       room.guesserCount(),
+		CorrectGuesses: state.correctGuessers(room),
+		Difficulty:     state.currentWord.Difficulty,
+		TimeLeft:       time.Until(state.endsAt),
+		DrawingTime:    time.Duration(room.Settings.DrawingTimeAllowed) * time.Second,
+		HintsRevealed:  state.hintsRevealed,
+		Rank:           getPlayerPositions(room.Players)[guesser.ID],
+	})
+}
+
 // Decodes a stroke from the payload
 func decodeStroke(payload interface{}) (Stroke, error) {
 	stroke, err := decodePayload[Stroke](payload)
@@ -192,12 +324,22 @@ func decodeStrokePoint(payload interface{}) ([]int, error) {
 	return point, nil
 }
 
-// Appends a stroke point to the most recent stroke
-func appendStrokePoint(strokes []Stroke, point []int) []Stroke {
+// Decodes a batch of stroke points from the payload
+func decodeStrokePoints(payload interface{}) ([][]int, error) {
+	points, err := decodePayload[[][]int](payload)
+	if err != nil {
+		slog.Warn("failed to decode stroke points", "error", err)
+		return [][]int{}, err
+	}
+	return points, nil
+}
+
+// Appends stroke points to the most recent stroke
+func appendStrokePoints(strokes []Stroke, points ...[]int) []Stroke {
 	if len(strokes) == 0 {
 		return strokes
 	}
-	strokes[len(strokes)-1].Points = append(strokes[len(strokes)-1].Points, point)
+	strokes[len(strokes)-1].Points = append(strokes[len(strokes)-1].Points, points...)
 	return strokes
 }
 
@@ -244,25 +386,45 @@ func isGuessClose(guess string, currentWord Word) bool {
 
 // Handles adding a stroke to the game state
 func (state *DrawingState) handleStroke(room *room, cmd *Command) error {
-	if cmd.Player.ID != room.currentDrawer.ID {
-		return ErrOnlyDrawerCanAddStrokes
+	if err := state.canDraw(room, cmd.Player, ErrOnlyDrawerCanAddStrokes); err != nil {
+		return err
 	}
 
 	if state.isDrawingPhaseOver() {
 		return nil // Silently ignore strokes after drawing phase ends
 	}
 
+	// Stays set if any of the checks below reject the stroke
+	state.strokeRejected = true
+
 	// Decode the stroke from the payload
 	stroke, err := decodeStroke(cmd.Payload)
 	if err != nil {
 		return fmt.Errorf("failed to decode stroke: %w", err)
 	}
 
+	if err := validateStroke(&stroke); err != nil {
+		return err
+	}
+
+	if state.strokeCount >= MAX_STROKES_PER_ROUND {
+		return ErrTooManyStrokes
+	}
+	if state.pointCount+len(stroke.Points) > MAX_STROKE_POINTS_PER_ROUND {
+		return ErrTooManyStrokePoints
+	}
+	state.strokeCount++
+	state.pointCount += len(stroke.Points)
+	state.strokeRejected = false
+
+	// Send any points from the previous stroke first so they don't end up on this one
+	state.flush(room)
+
 	// Add the stroke to the game state
 	state.strokes = append(state.strokes, stroke)
 
 	// Re-broadcast the stroke to the rest of the players
-	room.broadcast(GameRoleGuessing,
+	room.broadcastExcept(cmd.Player,
 		event(AddStrokeEvt, stroke),
 	)
 
@@ -271,35 +433,101 @@ func (state *DrawingState) handleStroke(room *room, cmd *Command) error {
 
 // Handles adding a stroke point to the most recent stroke
 func (state *DrawingState) handleStrokePoint(room *room, cmd *Command) error {
-	if cmd.Player.ID != room.currentDrawer.ID {
-		return ErrOnlyDrawerCanAddStrokePoints
+	if err := state.canDraw(room, cmd.Player, ErrOnlyDrawerCanAddStrokePoints); err != nil {
+		return err
 	}
 
 	if state.isDrawingPhaseOver() {
 		return nil // Silently ignore stroke points after drawing phase ends
 	}
 
+	if state.strokeRejected {
+		return nil // Drop points that belong to a rejected stroke
+	}
+
 	// Decode the stroke point from the payload
 	point, err := decodeStrokePoint(cmd.Payload)
 	if err != nil {
 		return fmt.Errorf("failed to decode stroke point: %w", err)
 	}
 
-	// Add the stroke point to the most recent stroke
-	state.strokes = appendStrokePoint(state.strokes, point)
+	return state.addStrokePoints(point)
+}
 
-	// Re-broadcast the stroke point to the rest of the players
-	room.broadcast(GameRoleGuessing,
-		event(AddStrokePointEvt, point),
-	)
+// Handles adding a batch of stroke points to the most recent stroke
+func (state *DrawingState) handleStrokePoints(room *room, cmd *Command) error {
+	if err := state.canDraw(room, cmd.Player, ErrOnlyDrawerCanAddStrokePoints); err != nil {
+		return err
+	}
+
+	if state.isDrawingPhaseOver() {
+		return nil // Silently ignore stroke points after drawing phase ends
+	}
+
+	if state.strokeRejected {
+		return nil // Drop points that belong to a rejected stroke
+	}
+
+	// Decode the stroke points from the payload
+	points, err := decodeStrokePoints(cmd.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode stroke points: %w", err)
+	}
+
+	return state.addStrokePoints(points...)
+}
+
+// Adds stroke points to the most recent stroke right away and
+// buffers them to be sent to the guessers on the next flush.
+//
+// A batch with any invalid points is rejected as a whole.
+func (state *DrawingState) addStrokePoints(points ...[]int) error {
+	if len(state.strokes) == 0 {
+		return nil
+	}
 
+	// Points can't be added to a fill
+	if state.strokes[len(state.strokes)-1].Type == StrokeTypeFill {
+		return commandErrorf(ErrCodeInvalidStroke, "can't add points to a fill")
+	}
+
+	if err := sanitizeStrokePoints(points); err != nil {
+		return err
+	}
+
+	if state.pointCount+len(points) > MAX_STROKE_POINTS_PER_ROUND {
+		return ErrTooManyStrokePoints
+	}
+	state.pointCount += len(points)
+
+	state.strokes = appendStrokePoints(state.strokes, points...)
+	state.pendingPoints = append(state.pendingPoints, points...)
 	return nil
 }
 
+// Sends the buffered stroke points to the guessers as a single event.
+//
+// The room calls this every Game.StrokeFlushInterval. Points are buffered in
+// between so guessers get one event per interval instead of one per pointer
+// move. It's also called before
+// any other canvas change is sent so guessers see everything in order.
+func (state *DrawingState) flush(room *room) {
+	if len(state.pendingPoints) == 0 {
+		return
+	}
+
+	room.broadcastExcept(state.activeDrawer(room),
+		event(AddStrokePointsEvt, state.pendingPoints),
+	)
+
+	// The old slice is now shared with the event, so start a new one
+	state.pendingPoints = nil
+}
+
 // Handles clearing the strokes from the game state
 func (state *DrawingState) handleClearStrokes(room *room, cmd *Command) error {
-	if cmd.Player.ID != room.currentDrawer.ID {
-		return ErrOnlyDrawerCanClearStrokes
+	if err := state.canDraw(room, cmd.Player, ErrOnlyDrawerCanClearStrokes); err != nil {
+		return err
 	}
 
 	if state.isDrawingPhaseOver() {
@@ -308,9 +536,10 @@ func (state *DrawingState) handleClearStrokes(room *room, cmd *Command) error {
 
 	// Clear the strokes from the game state
 	state.strokes = make([]Stroke, 0)
+	state.pendingPoints = nil
 
 	// Tell the other players to clear their strokes
-	room.broadcast(GameRoleGuessing,
+	room.broadcastExcept(cmd.Player,
 		event(ClearStrokesEvt, nil),
 	)
 	return nil
@@ -318,19 +547,22 @@ func (state *DrawingState) handleClearStrokes(room *room, cmd *Command) error {
 
 // Handles undoing the most recent stroke
 func (state *DrawingState) handleUndoStroke(room *room, cmd *Command) error {
-	if cmd.Player.ID != room.currentDrawer.ID {
-		return ErrOnlyDrawerCanUndoStroke
+	if err := state.canDraw(room, cmd.Player, ErrOnlyDrawerCanUndoStroke); err != nil {
+		return err
 	}
 
 	if state.isDrawingPhaseOver() {
 		return nil // Silently ignore undo stroke after drawing phase ends
 	}
 
+	// Send any buffered points before the undo so guessers remove the whole stroke
+	state.flush(room)
+
 	// Remove the most recent stroke from the game state
 	state.strokes = removeLastStroke(state.strokes)
 
 	// Tell the other players to undo their last stroke
-	room.broadcast(GameRoleGuessing,
+	room.broadcastExcept(cmd.Player,
 		event(UndoStrokeEvt, nil),
 	)
 	return nil
@@ -338,10 +570,6 @@ func (state *DrawingState) handleUndoStroke(room *room, cmd *Command) error {
 
 // Handles a chat message from a player
 func (state *DrawingState) handleChatMessage(room *room, cmd *Command) error {
-	if state.isDrawingPhaseOver() {
-		return fmt.Errorf("round is over")
-	}
-
 	player := cmd.Player
 	chatValue := cmd.Payload.(string)
 	msg := ChatMessage{
@@ -352,19 +580,36 @@ func (state *DrawingState) handleChatMessage(room *room, cmd *Command) error {
 	}
 	chatValue = sanitizeChatMessage(chatValue)
 
+	// The word is out once the drawing phase is over, so chat is posted as is
+	roundOver := state.isDrawingPhaseOver()
+
 	// If the player is not drawing and hasn't guessed correctly yet
 	// we check if the guess is exactly correct or close to the current word
-	if room.currentDrawer.ID != player.ID && state.pointsAwarded[player.ID] == 0 {
+	if !roundOver && !room.isDrawer(player.ID) && state.pointsAwarded[player.ID] == 0 && player.isActive() {
 		if strings.EqualFold(chatValue, state.currentWord.Value) {
-			guesserPoints, drawerPoints := GuessPoints(len(room.Players)-1, len(state.pointsAwarded), state.currentWord.Difficulty)
+			// Don't post the answer for everyone to see, the player can
+			// send it again once the steal opens. The room only posts chat
+			// itself for states that don't handle it, so this stays private.
+			if !state.canScore(room, player) {
+				return ErrStealNotOpen
+			}
+
+			guesserPoints, drawerPoints := state.guessPoints(room, player)
 
 			// Award the guesser points for guessing correctly
 			state.pointsAwarded[player.ID] = guesserPoints
 			player.Score += guesserPoints
-
-			// Award the drawer points for a player guessing their word correctly
-			state.pointsAwarded[room.currentDrawer.ID] += drawerPoints
-			room.currentDrawer.Score += drawerPoints
+			player.correctGuesses++
+
+			// Award the drawers points for a player guessing their word correctly,
+			// in team games they don't get any for the other team's steals
+			if !room.isTeamGame() || player.Team == room.leadDrawer().Team {
+				share := splitDrawerPoints(drawerPoints, len(room.currentDrawers))
+				for _, drawer := range room.currentDrawers {
+					state.pointsAwarded[drawer.ID] += share
+					drawer.Score += share
+				}
+			}
 
 			msg.Type = ChatMessageTypeCorrect
 			msg.Content = "" // Dont leak the correct answer to the other players
@@ -375,13 +620,21 @@ func (state *DrawingState) handleChatMessage(room *room, cmd *Command) error {
 			// If the guess is close, show a different message in chat
 			msg.Type = ChatMessageTypeCloseGuess
 		}
+	} else if !roundOver && !player.isActive() && strings.EqualFold(chatValue, state.currentWord.Value) {
+		// Players sitting out can't score, but they shouldn't give the answer away either
+		return ErrSittingOut
+	}
+
+	// Muted players can still guess the word, but nobody sees what else they write
+	if player.Muted && msg.Type != ChatMessageTypeCorrect {
+		return ErrMuted
 	}
 
 	// Broadcast the chat message to all players
 	room.handleChatMessage(msg)
 
 	// if all players have guessed correctly, end the drawing phase early
-	if len(state.pointsAwarded) >= len(room.Players) {
+	if !roundOver && state.correctGuessers(room) >= room.guesserCount() {
 		state.endPhaseEarly(room)
 	}
 
@@ -390,22 +643,120 @@ func (state *DrawingState) handleChatMessage(room *room, cmd *Command) error {
 
 // Handles a player leaving the game
 func (state *DrawingState) handlePlayerLeft(room *room, cmd *Command) error {
+	// Players who weren't in the game don't change who's left to guess
+	if !cmd.Player.isActive() {
+		return nil
+	}
+
 	delete(state.pointsAwarded, cmd.Player.ID)
+	delete(state.skipVotes, cmd.Player.ID)
+
+	// The player is still counted until they're unregistered
+	guessers := room.guesserCount()
+	if room.isDrawer(cmd.Player.ID) {
+		// A relay drawer leaving on their turn hands it to the next one
+		if cmd.Player == state.turnDrawer && len(room.currentDrawers) > 1 {
+			state.startTurn(room, state.nextDrawer(room))
+		}
+		return nil
+	}
+	guessers--
 
-	if len(state.pointsAwarded) >= len(room.Players)-1 {
+	if state.correctGuessers(room) >= guessers {
 		slog.Debug("player left, rest of players have guessed, advancing to next state")
 		state.endPhaseEarly(room)
+	} else if state.hasSkipMajority(guessers, room.config().Game.SkipWordMajority) {
+		// With fewer guessers left the votes already cast may be enough
+		state.skip(room)
+	}
+
+	return nil
+}
+
+// Records a guesser's vote to skip the drawing and skips it
+// once enough of the guessers have voted
+func (state *DrawingState) handleSkipVote(room *room, cmd *Command) error {
+	if state.isDrawingPhaseOver() {
+		return ErrRoundOver
+	}
+	player := cmd.Player
+	if room.isDrawer(player.ID) || !player.isActive() {
+		return ErrWrongGameRole
 	}
+	if state.skipVotes[player.ID] {
+		return nil
+	}
+	state.skipVotes[player.ID] = true
+
+	room.broadcast(GameRoleAny,
+		event(SetSkipVotesEvt, state.skipVoters()),
+	)
 
+	if state.hasSkipMajority(room.guesserCount(), room.config().Game.SkipWordMajority) {
+		state.skip(room)
+	}
 	return nil
 }
 
+// Checks if enough of the guessers voted to skip the drawing.
+// The drawing is skipped as soon as more than the majority share has voted,
+// or when every guesser has voted.
+func (state *DrawingState) hasSkipMajority(guessers int, majority float64) bool {
+	votes := len(state.skipVotes)
+	if votes == 0 || guessers <= 0 {
+		return false
+	}
+	return votes >= guessers || float64(votes) > majority*float64(guessers)
+}
+
+// Ends the drawing early without awarding any points for it,
+// points from guesses made before the skip are taken back
+func (state *DrawingState) skip(room *room) {
+	for id, points := range state.pointsAwarded {
+		p, ok := room.Players[id]
+		if !ok {
+			continue
+		}
+		p.Score -= points
+		if !room.isDrawer(p.ID) {
+			p.correctGuesses--
+		}
+	}
+	state.pointsAwarded = make(map[uuid.UUID]int)
+	state.skipped = true
+
+	slog.Debug("drawing skipped by vote", "word", state.currentWord.Value)
+	state.endPhaseEarly(room)
+}
+
+// Returns the IDs of the guessers who voted to skip the drawing
+func (state *DrawingState) skipVoters() []uuid.UUID {
+	voters := make([]uuid.UUID, 0, len(state.skipVotes))
+	for id := range state.skipVotes {
+		voters = append(voters, id)
+	}
+	return voters
+}
+
 // Handles a player joining the game
 func (state *DrawingState) handlePlayerJoined(room *room, cmd *Command) error {
+	// The drawer and players who already guessed it get the real word,
+	// this happens when they resume their session mid-drawing.
+	word := NewWord(state.hintedWord, state.currentWord.Difficulty)
+	if room.isDrawer(cmd.Player.ID) || state.pointsAwarded[cmd.Player.ID] > 0 {
+		word = state.currentWord
+	}
+
+	// Buffered points are already in the strokes we send, so flush them
+	// before the new player gets the strokes to keep them from being applied twice
+	state.flush(room)
+
 	// send the player the current drawing state
 	cmd.Player.Send(
 		event(SetStrokesEvt, state.strokes),
-		event(SetSelectedWordEvt, NewWord(state.hintedWord, state.currentWord.Difficulty)),
+		event(SetSkipVotesEvt, state.skipVoters()),
+		event(SetRelayTurnEvt, state.relayTurn()),
+		event(SetSelectedWordEvt, word),
 		event(SetCurrentStateEvt, Drawing),
 		event(SetTimerEvt, state.endsAt.UTC()),
 	)
@@ -437,6 +788,7 @@ func (state *DrawingState) applyHint() {
 	prevRunes[randomIndex] = fullRunes[randomIndex]
 
 	state.hintedWord = string(prevRunes)
+	state.hintsRevealed++
 }
 
 // Updates the streaks of all players and awards them a streak bonus
@@ -444,9 +796,17 @@ func (state *DrawingState) updateStreaks(room *room) {
 	playerPositions := getPlayerPositions(room.Players)
 
 	// Update the streaks of all players and award them a streak bonus
+	scorer := scorerFor(room.Settings.ScoringMode)
 	for _, p := range room.Players {
+		if !p.isActive() {
+			continue
+		}
 		state.updatePlayerStreak(p, room)
-		state.awardStreakBonus(p, playerPositions[p.ID], len(room.Players))
+		state.awardStreakBonus(p, scorer, BonusContext{
+			Rank:         playerPositions[p.ID],
+			TotalPlayers: len(room.Players),
+			Streak:       p.Streak,
+		})
 	}
 }
 
@@ -468,8 +828,8 @@ func (state *DrawingState) updatePlayerStreak(p *player, room *room) {
 
 // Awards a streak bonus to a player
 // and notifies them if they have lost a significant streak
-func (state *DrawingState) awardStreakBonus(p *player, position, totalPlayers int) {
-	streakBonus := StreakBonus(position, totalPlayers, p.Streak)
+func (state *DrawingState) awardStreakBonus(p *player, scorer Scorer, bonus BonusContext) {
+	streakBonus := scorer.BonusPoints(bonus)
 
 	if streakBonus > 0 {
 		state.pointsAwarded[p.ID] += streakBonus
@@ -479,7 +839,7 @@ func (state *DrawingState) awardStreakBonus(p *player, position, totalPlayers in
 			"player", p.ID,
 			"streak", p.Streak,
 			"streakBonus", streakBonus,
-			"playerPosition", position,
+			"playerPosition", bonus.Rank,
 		)
 	}
 }
@@ -487,13 +847,17 @@ func (state *DrawingState) awardStreakBonus(p *player, position, totalPlayers in
 // Generates a summary of the drawing phase
 func (state *DrawingState) drawingPhaseSummary(room *room) string {
 	drawer := "A player"
-	if room.currentDrawer != nil {
-		drawer = room.currentDrawer.Username
+	if len(room.currentDrawers) > 0 {
+		drawer = drawerNames(room.currentDrawers)
 	}
 
 	word := state.currentWord.Value
-	guessers := len(state.pointsAwarded) - 1 // -1 for the drawer
-	totalPlayers := len(room.Players)
+	if state.skipped {
+		return fmt.Sprintf("%s's drawing of %s was skipped.", drawer, word)
+	}
+
+	guessers := state.correctGuessers(room)
+	totalPlayers := room.guesserCount()
 
 	slog.Debug("Drawing phase summary",
 		"drawer", drawer,
@@ -515,6 +879,18 @@ func (state *DrawingState) drawingPhaseSummary(room *room) string {
 	}
 }
 
+// Joins the drawers' names for chat messages, ex. "Alice, Bob and Carol"
+func drawerNames(drawers []*player) string {
+	names := make([]string, len(drawers))
+	for i, d := range drawers {
+		names[i] = d.Username
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
 func pluralize(count int, word string) string {
 	if count > 1 {
 		return word + "s"
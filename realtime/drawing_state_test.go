@@ -1,8 +1,10 @@
 package main
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -32,8 +34,8 @@ func TestDrawingState_Permissions(t *testing.T) {
 		Settings: RoomSettings{
 			PlayerLimit: 10,
 		},
-		currentDrawer: drawer,
-		currentState:  NewDrawingState(Word{Value: "test", Difficulty: WordDifficultyEasy}),
+		currentDrawers: []*player{drawer},
+		currentState:   NewDrawingState(Word{Value: "test", Difficulty: WordDifficultyEasy}),
 	}
 
 	tests := []struct {
@@ -142,3 +144,282 @@ func TestDrawingState_Permissions(t *testing.T) {
 		})
 	}
 }
+
+func TestDrawingState_BatchesStrokePoints(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	guesser := &player{ID: uuid.New(), GameRole: GameRoleGuessing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer, guesser.ID: guesser},
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+	}
+
+	commands := []*Command{
+		{Type: AddStrokeCmd, Payload: Stroke{Color: "#000000", Width: 5, Type: "brush"}},
+		{Type: AddStrokePointCmd, Payload: []int{1, 1}},
+		{Type: AddStrokePointsCmd, Payload: [][]int{{2, 2}, {3, 3}}},
+	}
+	for _, cmd := range commands {
+		cmd.Player = drawer
+		if err := state.HandleCommand(room, cmd); err != nil {
+			t.Fatalf("unexpected error handling %s: %v", cmd.Type, err)
+		}
+	}
+
+	// Points are added to the stroke right away
+	want := [][]int{{1, 1}, {2, 2}, {3, 3}}
+	if !reflect.DeepEqual(state.strokes[0].Points, want) {
+		t.Errorf("expected stroke points %v, got %v", want, state.strokes[0].Points)
+	}
+
+	// But guessers only get them once they're flushed
+	if events := drainEvents(guesser.client); len(events) != 1 || events[0].Type != AddStrokeEvt {
+		t.Fatalf("expected only the stroke before flushing, got %d events", len(events))
+	}
+
+	state.flush(room)
+	events := drainEvents(guesser.client)
+	if len(events) != 1 || events[0].Type != AddStrokePointsEvt {
+		t.Fatalf("expected a single stroke points event after flushing, got %d events", len(events))
+	}
+	if !reflect.DeepEqual(events[0].Payload, want) {
+		t.Errorf("expected flushed points %v, got %v", want, events[0].Payload)
+	}
+
+	// Starting a new stroke sends the buffered points first so they stay on the old one
+	state.HandleCommand(room, &Command{Type: AddStrokePointCmd, Payload: []int{4, 4}, Player: drawer})
+	state.HandleCommand(room, &Command{Type: AddStrokeCmd, Payload: Stroke{Color: "#000000", Width: 5, Type: "brush"}, Player: drawer})
+	events = drainEvents(guesser.client)
+	if len(events) != 2 || events[0].Type != AddStrokePointsEvt || events[1].Type != AddStrokeEvt {
+		t.Errorf("expected buffered points to be sent before the new stroke, got %d events", len(events))
+	}
+	if len(drainEvents(drawer.client)) != 0 {
+		t.Error("expected the drawer not to get their own stroke points back")
+	}
+}
+
+func TestDrawingState_CapsStrokesPerRound(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer},
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+	}
+
+	addStroke := func() error {
+		return state.HandleCommand(room, &Command{
+			Type:    AddStrokeCmd,
+			Payload: Stroke{Color: "#000000", Width: 5, Type: "brush", Points: [][]int{{1, 1}}},
+			Player:  drawer,
+		})
+	}
+
+	for i := 0; i < MAX_STROKES_PER_ROUND; i++ {
+		if err := addStroke(); err != nil {
+			t.Fatalf("unexpected error adding stroke %d: %v", i, err)
+		}
+	}
+
+	// Clearing the canvas doesn't reset the limit
+	state.HandleCommand(room, &Command{Type: ClearStrokesCmd, Player: drawer})
+	if err := addStroke(); err != ErrTooManyStrokes {
+		t.Errorf("expected %v, got %v", ErrTooManyStrokes, err)
+	}
+}
+
+func TestDrawingState_CapsStrokePointsPerRound(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer},
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+	}
+
+	state.HandleCommand(room, &Command{Type: AddStrokeCmd, Payload: Stroke{Color: "#000000", Width: 5, Type: "brush"}, Player: drawer})
+
+	points := make([][]int, MAX_STROKE_POINTS_PER_ROUND)
+	for i := range points {
+		points[i] = []int{i % CANVAS_WIDTH, 0}
+	}
+	if err := state.HandleCommand(room, &Command{Type: AddStrokePointsCmd, Payload: points, Player: drawer}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := state.HandleCommand(room, &Command{Type: AddStrokePointCmd, Payload: []int{1, 1}, Player: drawer})
+	if err != ErrTooManyStrokePoints {
+		t.Errorf("expected %v, got %v", ErrTooManyStrokePoints, err)
+	}
+	if len(state.strokes[0].Points) != MAX_STROKE_POINTS_PER_ROUND {
+		t.Errorf("expected rejected points not to be stored, got %d points", len(state.strokes[0].Points))
+	}
+}
+
+func TestDrawingState_RejectsInvalidStrokePoints(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer},
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+	}
+
+	state.HandleCommand(room, &Command{Type: AddStrokeCmd, Payload: Stroke{Color: "#000000", Width: 5, Type: "brush"}, Player: drawer})
+
+	// One bad point rejects the whole batch
+	err := state.HandleCommand(room, &Command{Type: AddStrokePointsCmd, Payload: [][]int{{1, 1}, {2}}, Player: drawer})
+	if errorCodeOf(err) != ErrCodeInvalidStroke {
+		t.Errorf("expected %v, got %v", ErrCodeInvalidStroke, err)
+	}
+	if len(state.strokes[0].Points) != 0 {
+		t.Errorf("expected no points to be stored, got %v", state.strokes[0].Points)
+	}
+
+	// Points after a fill are rejected
+	state.HandleCommand(room, &Command{Type: AddStrokeCmd, Payload: Stroke{Color: "#000000", Type: "fill", Points: [][]int{{1, 1}}}, Player: drawer})
+	err = state.HandleCommand(room, &Command{Type: AddStrokePointCmd, Payload: []int{2, 2}, Player: drawer})
+	if errorCodeOf(err) != ErrCodeInvalidStroke {
+		t.Errorf("expected %v, got %v", ErrCodeInvalidStroke, err)
+	}
+}
+
+func TestDrawingState_DropsPointsAfterRejectedStroke(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer},
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+	}
+
+	state.HandleCommand(room, &Command{Type: AddStrokeCmd, Payload: Stroke{Color: "#000000", Width: 5, Type: "brush"}, Player: drawer})
+	state.HandleCommand(room, &Command{Type: AddStrokePointCmd, Payload: []int{1, 1}, Player: drawer})
+
+	// The points of a rejected stroke don't end up on the one before it
+	err := state.HandleCommand(room, &Command{Type: AddStrokeCmd, Payload: Stroke{Color: "red", Width: 5, Type: "brush"}, Player: drawer})
+	if errorCodeOf(err) != ErrCodeInvalidStroke {
+		t.Fatalf("expected %v, got %v", ErrCodeInvalidStroke, err)
+	}
+	state.HandleCommand(room, &Command{Type: AddStrokePointCmd, Payload: []int{2, 2}, Player: drawer})
+	state.HandleCommand(room, &Command{Type: AddStrokePointsCmd, Payload: [][]int{{3, 3}, {4, 4}}, Player: drawer})
+	if len(state.strokes) != 1 || len(state.strokes[0].Points) != 1 {
+		t.Fatalf("expected the first stroke to keep its one point, got %v", state.strokes)
+	}
+
+	// Points are accepted again once a stroke is
+	state.HandleCommand(room, &Command{Type: AddStrokeCmd, Payload: Stroke{Color: "#000000", Width: 5, Type: "brush"}, Player: drawer})
+	if err := state.HandleCommand(room, &Command{Type: AddStrokePointCmd, Payload: []int{5, 5}, Player: drawer}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(state.strokes) != 2 || len(state.strokes[1].Points) != 1 {
+		t.Errorf("expected the point on the new stroke, got %v", state.strokes)
+	}
+}
+
+func TestDrawingState_SkipVote(t *testing.T) {
+	drawer := &player{ID: uuid.New(), Username: "drawer", GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	a := &player{ID: uuid.New(), Username: "a", GameRole: GameRoleGuessing, client: NewClient(nil, nil, nil)}
+	b := &player{ID: uuid.New(), Username: "b", GameRole: GameRoleGuessing, client: NewClient(nil, nil, nil)}
+	c := &player{ID: uuid.New(), Username: "c", GameRole: GameRoleGuessing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test", Difficulty: WordDifficultyEasy}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer, a.ID: a, b.ID: b, c.ID: c},
+		ChatMessages:   make([]ChatMessage, 0),
+		scheduler:      NewGameScheduler(),
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+	}
+
+	// Someone guessed before the vote
+	state.HandleCommand(room, &Command{Type: ChatMessageCmd, Payload: "test", Player: a})
+	if a.Score == 0 || drawer.Score == 0 {
+		t.Fatal("expected the guess to score points")
+	}
+
+	if err := state.HandleCommand(room, &Command{Type: VoteSkipWordCmd, Player: drawer}); err != ErrWrongGameRole {
+		t.Errorf("expected the drawer not to be able to vote, got %v", err)
+	}
+
+	// One out of three guessers isn't enough
+	state.HandleCommand(room, &Command{Type: VoteSkipWordCmd, Player: b})
+	state.HandleCommand(room, &Command{Type: VoteSkipWordCmd, Player: b})
+	if state.skipped {
+		t.Fatal("expected the drawing not to be skipped with one vote")
+	}
+
+	// Two out of three is
+	state.HandleCommand(room, &Command{Type: VoteSkipWordCmd, Player: c})
+	if !state.skipped || !state.isDrawingPhaseOver() {
+		t.Fatal("expected the drawing to be skipped")
+	}
+
+	// Nobody gets points for a skipped drawing
+	if a.Score != 0 || drawer.Score != 0 || a.correctGuesses != 0 || len(state.pointsAwarded) != 0 {
+		t.Errorf("expected points to be taken back, got a=%d drawer=%d", a.Score, drawer.Score)
+	}
+
+	summary := room.ChatMessages[len(room.ChatMessages)-1]
+	if !strings.Contains(summary.Content, "skipped") {
+		t.Errorf("expected the summary to say the drawing was skipped, got %q", summary.Content)
+	}
+}
+
+func TestDrawingState_ChatAfterRoundOver(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	guesser := &player{ID: uuid.New(), GameRole: GameRoleGuessing, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(-time.Second)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer, guesser.ID: guesser},
+		ChatMessages:   make([]ChatMessage, 0),
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+		scheduler:      NewGameScheduler(),
+	}
+
+	// The word has been revealed, so it's posted without scoring
+	room.dispatch(&Command{Type: ChatMessageCmd, Player: guesser, Payload: "test"})
+	if len(room.ChatMessages) != 1 || room.ChatMessages[0].Type != ChatMessageTypeDefault {
+		t.Fatalf("expected the message to be posted as is, got %+v", room.ChatMessages)
+	}
+	if guesser.Score != 0 {
+		t.Errorf("expected no points after the round is over, got %d", guesser.Score)
+	}
+}
+
+func TestDrawingState_SittingOutKeepsAnswerPrivate(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, client: NewClient(nil, nil, nil)}
+	guesser := &player{ID: uuid.New(), GameRole: GameRoleGuessing, client: NewClient(nil, nil, nil)}
+	benched := &player{ID: uuid.New(), GameRole: GameRoleGuessing, SittingOut: true, client: NewClient(nil, nil, nil)}
+	state := NewDrawingState(Word{Value: "test"}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer, guesser.ID: guesser, benched.ID: benched},
+		ChatMessages:   make([]ChatMessage, 0),
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+		scheduler:      NewGameScheduler(),
+	}
+
+	room.dispatch(&Command{Type: ChatMessageCmd, Player: benched, Payload: "Test"})
+	if len(room.ChatMessages) != 0 {
+		t.Errorf("expected the answer not to be posted, got %+v", room.ChatMessages)
+	}
+	if benched.Score != 0 || len(state.pointsAwarded) != 0 {
+		t.Error("expected players sitting out not to score")
+	}
+
+	// Anything else they write is posted as usual
+	room.dispatch(&Command{Type: ChatMessageCmd, Player: benched, Payload: "nice drawing"})
+	if len(room.ChatMessages) != 1 || room.ChatMessages[0].Content != "nice drawing" {
+		t.Errorf("expected the message to be posted, got %+v", room.ChatMessages)
+	}
+}
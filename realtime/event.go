@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
 	"log/slog"
 )
 
@@ -13,7 +12,7 @@ import (
 type EventType string
 
 var (
-	ErrInvalidEvent = errors.New("invalid or unexpected event")
+	ErrInvalidEvent = &CommandError{ErrCodeInvalidCommand, "invalid or unexpected event"}
 )
 
 const (
@@ -24,27 +23,49 @@ const (
 )
 
 const (
-	AddStrokeEvt      EventType = "canvas/addStroke"
-	AddStrokePointEvt EventType = "canvas/addStrokePoint"
-	ClearStrokesEvt   EventType = "canvas/clearStrokes"
-	UndoStrokeEvt     EventType = "canvas/undoStroke"
-	SetStrokesEvt     EventType = "canvas/setStrokes"
+	AddStrokeEvt       EventType = "canvas/addStroke"
+	AddStrokePointEvt  EventType = "canvas/addStrokePoint"
+	AddStrokePointsEvt EventType = "canvas/addStrokePoints"
+	ClearStrokesEvt    EventType = "canvas/clearStrokes"
+	UndoStrokeEvt      EventType = "canvas/undoStroke"
+	SetStrokesEvt      EventType = "canvas/setStrokes"
 
-	SetPointsAwardedEvt EventType = "game/setPointsAwarded"
-	SetWordOptionsEvt   EventType = "game/setWordOptions"
-	SetSelectedWordEvt  EventType = "game/selectWord"
+	SetPointsAwardedEvt  EventType = "game/setPointsAwarded"
+	SetWordOptionsEvt    EventType = "game/setWordOptions"
+	SetSelectedWordEvt   EventType = "game/selectWord"
+	SetFinalStandingsEvt EventType = "game/setFinalStandings"
+	SetRematchVotesEvt   EventType = "game/setRematchVotes"
+	SetSkipVotesEvt      EventType = "game/setSkipVotes"
+	SetRelayTurnEvt      EventType = "game/setRelayTurn"
+
+	SetTeamPointsAwardedEvt EventType = "game/setTeamPointsAwarded"
+	SetTeamStandingsEvt     EventType = "game/setTeamStandings"
+
+	SetTelephoneTaskEvt      EventType = "telephone/setTask"
+	SetTelephoneSubmittedEvt EventType = "telephone/setSubmitted"
+	SetTelephoneChainsEvt    EventType = "telephone/setChains"
+	SetTelephoneRevealEvt    EventType = "telephone/setReveal"
 
 	RoomInitEvt           EventType = "room/init"
 	SetPlayerIdEvt        EventType = "room/setPlayerId"
 	SetPlayersEvt         EventType = "room/setPlayers"
+	HostChangedEvt        EventType = "room/hostChanged"
+	SetVoteKickEvt        EventType = "room/setVoteKick"
 	PlayerJoinedEvt       EventType = "room/playerJoined"
 	PlayerLeftEvt         EventType = "room/playerLeft"
+	PlayerDisconnectedEvt EventType = "room/playerDisconnected"
+	PlayerReconnectedEvt  EventType = "room/playerReconnected"
 	ChangeRoomSettingsEvt EventType = "room/changeRoomSettings"
 	SetChatEvt            EventType = "room/setChat"
 	NewChatMessageEvt     EventType = "room/newChatMessage"
+	SetSpectatorChatEvt   EventType = "room/setSpectatorChat"
+	NewSpectatorChatEvt   EventType = "room/newSpectatorChatMessage"
 	SetCurrentRoundEvt    EventType = "room/setCurrentRound"
 	SetCurrentStateEvt    EventType = "room/setCurrentState"
 	SetTimerEvt           EventType = "room/setTimer"
+
+	CommandAckEvt   EventType = "command/ack"
+	CommandErrorEvt EventType = "command/error"
 )
 
 type Event struct {
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// frame is a batch of events on its way to one or more clients.
+//
+// Broadcasts send the same frame to every recipient, so the events are
+// encoded once by whichever write routine gets to it first and every other
+// recipient reuses the same bytes. Frames must not be modified once sent.
+type frame struct {
+	events []*Event
+
+	once sync.Once
+	data []byte
+	err  error
+
+	// Messages for clients using the binary protocol, see binary_protocol.go
+	binaryOnce sync.Once
+	parts      []framePart
+}
+
+// A run of a frame's events encoded as a single websocket message.
+type framePart struct {
+	binary bool
+	data   []byte
+}
+
+func newFrame(events ...*Event) *frame {
+	if events == nil {
+		events = []*Event{}
+	}
+	return &frame{events: events}
+}
+
+// Encodes the frame's events the first time it's called,
+// later calls return the same result.
+func (f *frame) encode() ([]byte, error) {
+	f.once.Do(func() {
+		f.data, f.err = encodeEvents(f.events)
+	})
+	return f.data, f.err
+}
+
+// Splits the frame's events into runs of canvas events encoded with the
+// binary protocol and runs of everything else encoded as JSON. Like encode,
+// this only does the work once no matter how many clients the frame is sent to.
+func (f *frame) encodeParts() []framePart {
+	f.binaryOnce.Do(func() {
+		var jsonRun []*Event
+		var binaryRun []byte
+
+		flushJSON := func() {
+			if len(jsonRun) == 0 {
+				return
+			}
+			data, err := encodeEvents(jsonRun)
+			if err != nil {
+				slog.Warn("error encoding frame", "error", err)
+			} else {
+				f.parts = append(f.parts, framePart{binary: false, data: data})
+			}
+			jsonRun = nil
+		}
+		flushBinary := func() {
+			if len(binaryRun) == 0 {
+				return
+			}
+			f.parts = append(f.parts, framePart{binary: true, data: binaryRun})
+			binaryRun = nil
+		}
+
+		for _, evt := range f.events {
+			if isBinaryEvent(evt) {
+				if buf, ok := appendBinaryEvent(binaryRun, evt); ok {
+					flushJSON()
+					binaryRun = buf
+					continue
+				}
+			}
+			flushBinary()
+			jsonRun = append(jsonRun, evt)
+		}
+		flushJSON()
+		flushBinary()
+	})
+	return f.parts
+}
+
+// Writes frames to a websocket connection using the binary protocol.
+//
+// Adjacent parts of the same kind are merged, so this writes as few
+// messages as possible while keeping the events in order.
+func writeFramesBinary(conn *websocket.Conn, frames []*frame) error {
+	var merged []framePart
+	var jsonArrays [][]byte
+
+	flushJSON := func() {
+		if len(jsonArrays) > 0 {
+			merged = append(merged, framePart{binary: false, data: joinJSONArrays(jsonArrays)})
+			jsonArrays = nil
+		}
+	}
+
+	for _, f := range frames {
+		for _, part := range f.encodeParts() {
+			if !part.binary {
+				jsonArrays = append(jsonArrays, part.data)
+				continue
+			}
+			flushJSON()
+
+			// Binary records are self delimiting, so they can just be concatenated
+			if last := len(merged) - 1; last >= 0 && merged[last].binary {
+				merged[last].data = append(merged[last].data[:len(merged[last].data):len(merged[last].data)], part.data...)
+			} else {
+				merged = append(merged, part)
+			}
+		}
+	}
+	flushJSON()
+
+	for _, part := range merged {
+		messageType := websocket.TextMessage
+		if part.binary {
+			messageType = websocket.BinaryMessage
+		}
+		if err := conn.WriteMessage(messageType, part.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes frames to a websocket connection as a single message.
+//
+// A lone frame is written straight from its shared bytes. Multiple frames
+// are spliced into one JSON array without decoding them again.
+//
+// We don't use websocket.PreparedMessage here, it allocates a full write
+// buffer per message which costs more than it saves for small events
+// like stroke points.
+func writeFrames(conn *websocket.Conn, frames []*frame) error {
+	if len(frames) == 1 {
+		data, err := frames[0].encode()
+		if err != nil {
+			slog.Warn("error encoding frame", "error", err)
+			return nil
+		}
+		return conn.WriteMessage(websocket.TextMessage, data)
+	}
+	return conn.WriteMessage(websocket.TextMessage, joinFrames(frames))
+}
+
+// Splices the encoded event arrays of several frames into one array.
+// Frames that fail to encode are skipped.
+func joinFrames(frames []*frame) []byte {
+	arrays := make([][]byte, 0, len(frames))
+	for _, f := range frames {
+		data, err := f.encode()
+		if err != nil {
+			slog.Warn("error encoding frame", "error", err)
+			continue
+		}
+		arrays = append(arrays, data)
+	}
+	return joinJSONArrays(arrays)
+}
+
+// Splices encoded JSON arrays into one array without decoding them.
+func joinJSONArrays(arrays [][]byte) []byte {
+	if len(arrays) == 1 {
+		return arrays[0]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for _, data := range arrays {
+		// Strip the brackets and skip empty arrays
+		inner := data[1 : len(data)-1]
+		if len(inner) == 0 {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(inner)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
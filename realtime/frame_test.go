@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJoinFrames(t *testing.T) {
+	frames := []*frame{
+		newFrame(event(AddStrokePointEvt, []int{1, 2})),
+		newFrame(),
+		newFrame(event(UndoStrokeEvt, nil), event(ClearStrokesEvt, nil)),
+	}
+
+	var events []Event
+	if err := json.Unmarshal(joinFrames(frames), &events); err != nil {
+		t.Fatalf("joined frames are not valid JSON: %v", err)
+	}
+
+	want := []EventType{AddStrokePointEvt, UndoStrokeEvt, ClearStrokesEvt}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, evt := range events {
+		if evt.Type != want[i] {
+			t.Errorf("expected event %d to be %s, got %s", i, want[i], evt.Type)
+		}
+	}
+}
+
+func TestFrame_EncodesOnce(t *testing.T) {
+	f := newFrame(event(AddStrokePointEvt, []int{1, 2}))
+
+	first, err := f.encode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := f.encode()
+	if &first[0] != &second[0] {
+		t.Error("expected every recipient to share the same encoded bytes")
+	}
+}
+
+// Number of guessers receiving each stroke point in a full room
+var benchmarkRecipients = DefaultConfig().Rooms.MaxPlayers - 1
+
+// How broadcasts used to work: every recipient's write routine encoded the events itself.
+func BenchmarkBroadcastStrokePoint_EncodePerRecipient(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		events := []*Event{event(AddStrokePointEvt, []int{i, i})}
+		for r := 0; r < benchmarkRecipients; r++ {
+			if _, err := encodeEvents(events); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// How broadcasts work now: the events are encoded once and every recipient shares the frame.
+func BenchmarkBroadcastStrokePoint_SharedFrame(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f := newFrame(event(AddStrokePointEvt, []int{i, i}))
+		for r := 0; r < benchmarkRecipients; r++ {
+			if _, err := f.encode(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
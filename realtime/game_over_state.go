@@ -1,38 +1,208 @@
 package main
 
-import "log/slog"
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FinalStanding is a player's result at the end of a game
+type FinalStanding struct {
+	PlayerID       uuid.UUID `json:"playerId"`
+	Username       string    `json:"username"`
+	Rank           int       `json:"rank"` // Players with the same score share a rank
+	Score          int       `json:"score"`
+	WordsDrawn     []string  `json:"wordsDrawn"`
+	CorrectGuesses int       `json:"correctGuesses"`
+	Team           Team      `json:"team,omitempty"`
+}
 
 // The game over state is the final state of the game.
 // It is entered when the game is over and the players have no more rounds to play.
-// It is used to display the final scoreboard and announce the winner.
-// ! This state is not in use yet.
+// It is used to display the final standings and announce the winner
+// before everyone goes back to the lobby.
 type GameOverState struct {
+	standings     []FinalStanding
+	teamStandings []TeamStanding // Only set in team games
+	endsAt        time.Time
+
+	// Players who voted to play again
+	rematchVotes map[uuid.UUID]bool
 }
 
 func NewGameOverState() RoomState {
-	return &GameOverState{}
+	return &GameOverState{
+		rematchVotes: make(map[uuid.UUID]bool),
+	}
 }
 
-func (state GameOverState) Enter(room *room) {
+func (state *GameOverState) Enter(room *room) {
 	slog.Debug("Game over enter")
-	room.broadcast(GameRoleAny, event(SetCurrentStateEvt, GameOver))
+	state.standings = finalStandings(room.Players)
+	state.endsAt = time.Now().Add(room.config().Game.GameOverDuration.Duration)
+
+	if room.isTeamGame() {
+		state.teamStandings = teamStandings(room.Players)
+	}
+
+	// Keep the standings around so the lobby can show them until the next game starts
+	room.finalStandings = state.standings
+	room.teamStandings = state.teamStandings
+
+	room.scheduler.addEvent(ScheduledStateChange, state.endsAt, func() {
+		room.Transition()
+	})
+
+	room.broadcast(GameRoleAny,
+		event(SetFinalStandingsEvt, state.standings),
+		event(SetTeamStandingsEvt, state.teamStandings),
+		event(SetRematchVotesEvt, state.voters()),
+		event(SetCurrentStateEvt, GameOver),
+		event(SetTimerEvt, state.endsAt.UTC()),
+	)
 }
 
-func (state GameOverState) Exit(room *room) {
+func (state *GameOverState) Exit(room *room) {
 	slog.Debug("Game over exit")
+	room.scheduler.cancelEvent(ScheduledStateChange)
+	room.setState(NewWaitingState())
 }
 
-func (state GameOverState) handlePlayerJoined(room *room, cmd *Command) error {
+func (state *GameOverState) handlePlayerJoined(room *room, cmd *Command) error {
 	cmd.Player.Send(
+		event(SetFinalStandingsEvt, state.standings),
+		event(SetTeamStandingsEvt, state.teamStandings),
+		event(SetRematchVotesEvt, state.voters()),
 		event(SetCurrentStateEvt, GameOver),
+		event(SetTimerEvt, state.endsAt.UTC()),
 	)
 	return nil
 }
 
-func (state GameOverState) HandleCommand(room *room, cmd *Command) error {
+func (state *GameOverState) HandleCommand(room *room, cmd *Command) error {
 	switch cmd.Type {
 	case PlayerJoinedCmd:
 		return state.handlePlayerJoined(room, cmd)
+	case PlayerLeftCmd:
+		return state.handlePlayerLeft(room, cmd)
+	case VoteRematchCmd:
+		return state.handleRematchVote(room, cmd)
+	default:
+		slog.Error("Invalid command for current state", "command", cmd.Type)
+		return ErrInvalidCommand
+	}
+}
+
+// Records a player's vote to play again and starts the
+// rematch once enough players have voted
+func (state *GameOverState) handleRematchVote(room *room, cmd *Command) error {
+	if cmd.Player.isSpectator() {
+		return ErrWrongRoomRole
+	}
+	if state.rematchVotes[cmd.Player.ID] {
+		return nil
+	}
+	state.rematchVotes[cmd.Player.ID] = true
+
+	room.broadcast(GameRoleAny,
+		event(SetRematchVotesEvt, state.voters()),
+	)
+
+	if state.hasRematchMajority(room.playerCount(), room.config().Game.RematchMajority) {
+		state.startRematch(room)
 	}
 	return nil
 }
+
+// Drops the vote of a player who left. We don't start the rematch here
+// since the player is still in the room until they're fully unregistered,
+// the next vote will check again.
+func (state *GameOverState) handlePlayerLeft(room *room, cmd *Command) error {
+	if !state.rematchVotes[cmd.Player.ID] {
+		return nil
+	}
+	delete(state.rematchVotes, cmd.Player.ID)
+
+	room.broadcast(GameRoleAny,
+		event(SetRematchVotesEvt, state.voters()),
+	)
+	return nil
+}
+
+// Checks if enough of the players voted for a rematch.
+// The rematch starts as soon as more than the majority share has voted,
+// or when everyone has voted.
+func (state *GameOverState) hasRematchMajority(players int, majority float64) bool {
+	votes := len(state.rematchVotes)
+	if votes < MIN_PLAYERS {
+		return false
+	}
+	return votes >= players || float64(votes) > majority*float64(players)
+}
+
+// Starts the next game right away with the players who voted for it,
+// everyone else sits out until the room goes back to the lobby.
+func (state *GameOverState) startRematch(room *room) {
+	for _, p := range room.Players {
+		p.SittingOut = !state.rematchVotes[p.ID] && !p.isSpectator()
+	}
+
+	// Start the game the same way the host does from the lobby
+	room.scheduler.cancelEvent(ScheduledStateChange)
+	lobby := NewWaitingState().(*WaitingState)
+	room.setState(lobby)
+
+	if err := canStartGame(room); err != nil {
+		slog.Warn("rematch could not start, returning to lobby", "error", err)
+		room.enterState()
+		return
+	}
+
+	room.SendSystemMessage("Rematch! Starting a new game")
+	lobby.startGame(room)
+}
+
+// Returns the IDs of the players who voted for a rematch
+func (state *GameOverState) voters() []uuid.UUID {
+	voters := make([]uuid.UUID, 0, len(state.rematchVotes))
+	for id := range state.rematchVotes {
+		voters = append(voters, id)
+	}
+	return voters
+}
+
+// Ranks players by score, players with the same score share a rank.
+// Spectators didn't play, so they aren't ranked.
+func finalStandings(players map[uuid.UUID]*player) []FinalStanding {
+	standings := make([]FinalStanding, 0, len(players))
+
+	for _, id := range getSortedPlayersByScore(players) {
+		p := players[id]
+		if p.isSpectator() {
+			continue
+		}
+
+		rank := len(standings) + 1
+		if last := len(standings) - 1; last >= 0 && standings[last].Score == p.Score {
+			rank = standings[last].Rank
+		}
+
+		wordsDrawn := p.wordsDrawn
+		if wordsDrawn == nil {
+			wordsDrawn = []string{}
+		}
+
+		standings = append(standings, FinalStanding{
+			PlayerID:       p.ID,
+			Username:       p.Username,
+			Rank:           rank,
+			Score:          p.Score,
+			WordsDrawn:     wordsDrawn,
+			CorrectGuesses: p.correctGuesses,
+			Team:           p.Team,
+		})
+	}
+
+	return standings
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFinalStandings(t *testing.T) {
+	alice := &player{ID: uuid.New(), Username: "alice", Score: 300, wordsDrawn: []string{"cat"}, correctGuesses: 2}
+	bob := &player{ID: uuid.New(), Username: "bob", Score: 500, wordsDrawn: []string{"dog", "bird"}, correctGuesses: 1}
+	carol := &player{ID: uuid.New(), Username: "carol", Score: 300}
+	dave := &player{ID: uuid.New(), Username: "dave", Score: 100}
+
+	standings := finalStandings(map[uuid.UUID]*player{
+		alice.ID: alice,
+		bob.ID:   bob,
+		carol.ID: carol,
+		dave.ID:  dave,
+	})
+
+	want := []FinalStanding{
+		{PlayerID: bob.ID, Username: "bob", Rank: 1, Score: 500, WordsDrawn: []string{"dog", "bird"}, CorrectGuesses: 1},
+		{PlayerID: alice.ID, Username: "alice", Rank: 2, Score: 300, WordsDrawn: []string{"cat"}, CorrectGuesses: 2},
+		{PlayerID: carol.ID, Username: "carol", Rank: 2, Score: 300, WordsDrawn: []string{}},
+		{PlayerID: dave.ID, Username: "dave", Rank: 4, Score: 100, WordsDrawn: []string{}},
+	}
+	if !reflect.DeepEqual(standings, want) {
+		t.Errorf("expected standings %+v, got %+v", want, standings)
+	}
+}
+
+func TestGameOverState_ReturnsToLobbyWithStandings(t *testing.T) {
+	host := &player{ID: uuid.New(), Username: "host", RoomRole: RoomRoleHost, Score: 200, client: NewClient(nil, nil, nil)}
+	guest := &player{ID: uuid.New(), Username: "guest", RoomRole: RoomRolePlayer, Score: 100, client: NewClient(nil, nil, nil)}
+
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID:  host,
+			guest.ID: guest,
+		},
+		Settings:     RoomSettings{TotalRounds: 1, WordDifficulty: WordDifficultyEasy, WordBank: WordBankDefault},
+		CurrentRound: 1,
+		drawingQueue: make([]uuid.UUID, 0),
+		scheduler:    NewGameScheduler(),
+		currentState: NewPostDrawingState(map[uuid.UUID]int{}),
+	}
+
+	// Finishing the last round ends the game
+	room.Transition()
+	if _, ok := room.currentState.(*GameOverState); !ok {
+		t.Fatalf("expected game over state after the last round, got %T", room.currentState)
+	}
+	if len(room.finalStandings) != 2 || room.finalStandings[0].PlayerID != host.ID {
+		t.Fatalf("expected standings to be kept on the room, got %+v", room.finalStandings)
+	}
+
+	// Then goes back to the lobby with scores intact
+	room.Transition()
+	if _, ok := room.currentState.(*WaitingState); !ok {
+		t.Fatalf("expected waiting state after game over, got %T", room.currentState)
+	}
+	if host.Score != 200 || guest.Score != 100 {
+		t.Errorf("expected scores to be kept in the lobby, got %d and %d", host.Score, guest.Score)
+	}
+
+	// Players joining the lobby still see the results
+	newcomer := &player{ID: uuid.New(), client: NewClient(nil, nil, nil)}
+	room.Players[newcomer.ID] = newcomer
+	drainEvents(newcomer.client)
+	room.currentState.HandleCommand(room, &Command{Type: PlayerJoinedCmd, Player: newcomer})
+	if events := drainEvents(newcomer.client); len(events) == 0 || events[0].Type != SetFinalStandingsEvt {
+		t.Error("expected players joining the lobby to get the final standings")
+	}
+
+	// Until the next game starts
+	if err := room.currentState.HandleCommand(room, &Command{Type: StartGameCmd, Player: host}); err != nil {
+		t.Fatalf("unexpected error starting the game: %v", err)
+	}
+	if room.finalStandings != nil || host.Score != 0 {
+		t.Error("expected standings and scores to be reset when the next game starts")
+	}
+}
+
+func TestGameOverState_HasRematchMajority(t *testing.T) {
+	tests := []struct {
+		name    string
+		votes   int
+		players int
+		want    bool
+	}{
+		{name: "everyone voted", votes: 2, players: 2, want: true},
+		{name: "more than half voted", votes: 3, players: 5, want: true},
+		{name: "exactly half voted", votes: 2, players: 4, want: false},
+		{name: "less than half voted", votes: 2, players: 6, want: false},
+		{name: "not enough voters for a game", votes: 1, players: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewGameOverState().(*GameOverState)
+			for i := 0; i < tt.votes; i++ {
+				state.rematchVotes[uuid.New()] = true
+			}
+			if got := state.hasRematchMajority(tt.players, DefaultConfig().Game.RematchMajority); got != tt.want {
+				t.Errorf("hasRematchMajority(%d) with %d votes = %v, want %v", tt.players, tt.votes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameOverState_RematchStartsWithVoters(t *testing.T) {
+	alice := &player{ID: uuid.New(), Username: "alice", RoomRole: RoomRoleHost, Score: 300, client: NewClient(nil, nil, nil)}
+	bob := &player{ID: uuid.New(), Username: "bob", RoomRole: RoomRolePlayer, Score: 200, client: NewClient(nil, nil, nil)}
+	carol := &player{ID: uuid.New(), Username: "carol", RoomRole: RoomRolePlayer, Score: 100, client: NewClient(nil, nil, nil)}
+
+	state := NewGameOverState().(*GameOverState)
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			alice.ID: alice,
+			bob.ID:   bob,
+			carol.ID: carol,
+		},
+		Settings:     RoomSettings{TotalRounds: 2, WordDifficulty: WordDifficultyEasy, WordBank: WordBankDefault},
+		CurrentRound: 2,
+		drawingQueue: make([]uuid.UUID, 0),
+		scheduler:    NewGameScheduler(),
+		currentState: state,
+	}
+	state.Enter(room)
+
+	// One vote isn't enough
+	state.HandleCommand(room, &Command{Type: VoteRematchCmd, Player: alice})
+	if room.currentState != state {
+		t.Fatal("expected the rematch to wait for more votes")
+	}
+
+	// Two out of three is a majority
+	state.HandleCommand(room, &Command{Type: VoteRematchCmd, Player: bob})
+	if _, ok := room.currentState.(*PickingState); !ok {
+		t.Fatalf("expected the rematch to start, got %T", room.currentState)
+	}
+	if room.CurrentRound != 1 || alice.Score != 0 {
+		t.Error("expected the rematch to start a fresh game")
+	}
+
+	// Carol didn't vote, so she sits out and never gets to draw
+	if !carol.SittingOut || alice.SittingOut || bob.SittingOut {
+		t.Errorf("expected only non-voters to sit out, got alice=%v bob=%v carol=%v",
+			alice.SittingOut, bob.SittingOut, carol.SittingOut)
+	}
+	if room.isDrawer(carol.ID) {
+		t.Error("expected a player sitting out not to be picked to draw")
+	}
+	for _, id := range room.drawingQueue {
+		if id == carol.ID {
+			t.Error("expected a player sitting out not to be in the drawing queue")
+		}
+	}
+}
+
+func TestGameOverState_HandleCommand_Unhandled(t *testing.T) {
+	host := &player{ID: uuid.New(), Username: "host", RoomRole: RoomRoleHost, client: NewClient(nil, nil, nil)}
+	guest := &player{ID: uuid.New(), Username: "guest", RoomRole: RoomRolePlayer, client: NewClient(nil, nil, nil)}
+
+	state := NewGameOverState().(*GameOverState)
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID:  host,
+			guest.ID: guest,
+		},
+		ChatMessages: make([]ChatMessage, 0),
+		drawingQueue: make([]uuid.UUID, 0),
+		scheduler:    NewGameScheduler(),
+		currentState: state,
+	}
+	state.Enter(room)
+
+	// Commands meant for other phases aren't acknowledged as if they worked
+	if err := state.HandleCommand(room, &Command{Type: StartGameCmd, Player: host}); !errors.Is(err, ErrInvalidCommand) {
+		t.Errorf("expected %v, got %v", ErrInvalidCommand, err)
+	}
+
+	// So chat falls through to the room and everyone still sees it
+	room.dispatch(&Command{Type: ChatMessageCmd, Player: guest, Payload: "gg"})
+	if len(room.ChatMessages) != 1 || room.ChatMessages[0].Content != "gg" {
+		t.Errorf("expected the chat message to be posted, got %+v", room.ChatMessages)
+	}
+}
@@ -24,6 +24,10 @@ type ScheduledEvent struct {
 	isRecurring bool
 	runCount    int
 	runLimit    int
+
+	// Room events aren't tied to the current state, so clearing
+	// the state's events on a transition leaves them alone
+	isRoomEvent bool
 }
 
 type GameScheduler struct {
@@ -45,6 +49,7 @@ func (s *GameScheduler) tick(delta time.Duration) {
 		if s.now.After(event.nextRunAt) {
 			slog.Debug("calling handler", "id", id)
 			event.handler()
+			scheduledEventsFired.inc(string(id))
 
 			if event.isRecurring {
 				event.runCount++
@@ -74,6 +79,16 @@ func (s *GameScheduler) addEvent(eventType ScheduledEventType, when time.Time, h
 	return event, nil
 }
 
+// Adds a one-off event that isn't tied to the current state, see isRoomEvent
+func (s *GameScheduler) addRoomEvent(eventType ScheduledEventType, when time.Time, handler func()) (evt *ScheduledEvent, err error) {
+	event, err := s.addEvent(eventType, when, handler)
+	if err != nil {
+		return nil, err
+	}
+	event.isRoomEvent = true
+	return event, nil
+}
+
 func (s *GameScheduler) addReccuringEvent(eventType ScheduledEventType, interval time.Duration, runLimit int, handler func()) (evt *ScheduledEvent, err error) {
 	event := &ScheduledEvent{
 		nextRunAt:   time.Now().Add(interval),
@@ -105,6 +120,11 @@ func (s *GameScheduler) cancelEvent(eventType ScheduledEventType) error {
 	return nil
 }
 
+// Clears the events of the current state, room events are kept
 func (s *GameScheduler) clearEvents() {
-	s.events = make(map[ScheduledEventType]*ScheduledEvent)
+	for id, event := range s.events {
+		if !event.isRoomEvent {
+			delete(s.events, id)
+		}
+	}
 }
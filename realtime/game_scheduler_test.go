@@ -23,3 +23,20 @@ func TestGameScheduler(t *testing.T) {
 		t.Errorf("Expected 1 execution, got %d", executionCount)
 	}
 }
+
+func TestGameScheduler_ClearEventsKeepsRoomEvents(t *testing.T) {
+	scheduler := NewGameScheduler()
+	when := time.Now().Add(time.Minute)
+
+	scheduler.addEvent(ScheduledStateChange, when, func() {})
+	scheduler.addRoomEvent(ScheduledVoteKickEnd, when, func() {})
+
+	scheduler.clearEvents()
+
+	if scheduler.events[ScheduledStateChange] != nil {
+		t.Error("expected state events to be cleared")
+	}
+	if scheduler.events[ScheduledVoteKickEnd] == nil {
+		t.Error("expected room events to be kept")
+	}
+}
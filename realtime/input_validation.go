@@ -10,6 +10,25 @@ const (
 	// Maximum length of a word
 	MAX_WORD_LENGTH = 24
 	MAX_CHAT_LENGTH = 128
+
+	// Maximum length of a prompt or description in a telephone game
+	MAX_TELEPHONE_TEXT_LENGTH = 100
+)
+
+const (
+	// Logical size of the canvas, stroke points are clamped to these bounds.
+	// This is the size of the frontend's canvas buffer, not the size it's displayed at.
+	CANVAS_WIDTH  = 1600
+	CANVAS_HEIGHT = 1200
+
+	MIN_STROKE_WIDTH = 1
+	MAX_STROKE_WIDTH = 50
+)
+
+const (
+	StrokeTypeBrush  = "brush"
+	StrokeTypeEraser = "eraser"
+	StrokeTypeFill   = "fill"
 )
 
 func sanitizeUsername(username string) string {
@@ -130,18 +149,82 @@ func sanitizeChatMessage(message string) string {
 	return trimed
 }
 
+// Trims a telephone prompt or description, returns an empty string if it's empty or too long
+func sanitizeTelephoneText(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if len(trimmed) > MAX_TELEPHONE_TEXT_LENGTH {
+		return ""
+	}
+	return trimmed
+}
+
+// validateStroke checks a stroke sent by the drawer and clamps its points to the canvas
+func validateStroke(stroke *Stroke) error {
+	// Older clients don't always send a type, the canvas draws those as brush strokes
+	if stroke.Type == "" {
+		stroke.Type = StrokeTypeBrush
+	}
+
+	switch stroke.Type {
+	case StrokeTypeBrush, StrokeTypeEraser:
+		if stroke.Width < MIN_STROKE_WIDTH || stroke.Width > MAX_STROKE_WIDTH {
+			return commandErrorf(ErrCodeInvalidStroke, "stroke width must be between %d and %d", MIN_STROKE_WIDTH, MAX_STROKE_WIDTH)
+		}
+	case StrokeTypeFill:
+		// Fills only have a starting point, the width isn't used
+		if len(stroke.Points) != 1 {
+			return commandErrorf(ErrCodeInvalidStroke, "fill must have exactly one point")
+		}
+		stroke.Width = 0
+	default:
+		return commandErrorf(ErrCodeInvalidStroke, "invalid stroke type: %s", stroke.Type)
+	}
+
+	if !isHexColor(stroke.Color) {
+		return commandErrorf(ErrCodeInvalidStroke, "invalid stroke color: %s", stroke.Color)
+	}
+
+	return sanitizeStrokePoints(stroke.Points)
+}
+
+// sanitizeStrokePoints checks that every point is an x, y pair
+// and clamps them to the canvas
+func sanitizeStrokePoints(points [][]int) error {
+	for _, point := range points {
+		if len(point) != 2 {
+			return commandErrorf(ErrCodeInvalidStroke, "stroke points must have 2 coordinates, got %d", len(point))
+		}
+		point[0] = min(max(point[0], 0), CANVAS_WIDTH)
+		point[1] = min(max(point[1], 0), CANVAS_HEIGHT)
+	}
+	return nil
+}
+
+// Checks if a color is in the #rrggbb format the canvas expects
+func isHexColor(color string) bool {
+	if len(color) != 7 || color[0] != '#' {
+		return false
+	}
+	for _, r := range color[1:] {
+		if !((r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
+
 // validateRoomSettings checks if room settings are within allowed bounds
-func validateRoomSettings(settings *RoomSettings) error {
-	if settings.PlayerLimit < MIN_PLAYERS || settings.PlayerLimit > MAX_PLAYERS {
-		return fmt.Errorf("player limit must be between %d and %d", MIN_PLAYERS, MAX_PLAYERS)
+func validateRoomSettings(settings *RoomSettings, limits RoomsConfig) error {
+	if settings.PlayerLimit < MIN_PLAYERS || settings.PlayerLimit > limits.MaxPlayers {
+		return commandErrorf(ErrCodeInvalidSettings, "player limit must be between %d and %d", MIN_PLAYERS, limits.MaxPlayers)
 	}
 
-	if settings.DrawingTimeAllowed < MIN_DRAWING_TIME || settings.DrawingTimeAllowed > MAX_DRAWING_TIME {
-		return fmt.Errorf("drawing time must be between %d and %d seconds", MIN_DRAWING_TIME, MAX_DRAWING_TIME)
+	if settings.DrawingTimeAllowed < limits.MinDrawingTime || settings.DrawingTimeAllowed > limits.MaxDrawingTime {
+		return commandErrorf(ErrCodeInvalidSettings, "drawing time must be between %d and %d seconds", limits.MinDrawingTime, limits.MaxDrawingTime)
 	}
 
-	if settings.TotalRounds < MIN_ROUNDS || settings.TotalRounds > MAX_ROUNDS {
-		return fmt.Errorf("total rounds must be between %d and %d", MIN_ROUNDS, MAX_ROUNDS)
+	if settings.TotalRounds < limits.MinRounds || settings.TotalRounds > limits.MaxRounds {
+		return commandErrorf(ErrCodeInvalidSettings, "total rounds must be between %d and %d", limits.MinRounds, limits.MaxRounds)
 	}
 
 	// Validate word difficulty
@@ -149,7 +232,7 @@ func validateRoomSettings(settings *RoomSettings) error {
 	case WordDifficultyEasy, WordDifficultyMedium, WordDifficultyHard, WordDifficultyAll, WordDifficultyCustom:
 		// Valid values
 	default:
-		return fmt.Errorf("invalid word difficulty: %s", settings.WordDifficulty)
+		return commandErrorf(ErrCodeInvalidSettings, "invalid word difficulty: %s", settings.WordDifficulty)
 	}
 
 	// Validate word bank
@@ -157,15 +240,24 @@ func validateRoomSettings(settings *RoomSettings) error {
 	case WordBankDefault, WordBankCustom, WordBankMixed:
 		// Valid values
 	default:
-		return fmt.Errorf("invalid word bank: %s", settings.WordBank)
+		return commandErrorf(ErrCodeInvalidSettings, "invalid word bank: %s", settings.WordBank)
 	}
 
 	// Validate game mode
 	switch settings.GameMode {
-	case GameModeClassic, GameModeNoHints:
+	case GameModeClassic, GameModeNoHints, GameModeTeams, GameModeTelephone, GameModeRelay:
 		// Valid values
 	default:
-		return fmt.Errorf("invalid game mode: %s", settings.GameMode)
+		return commandErrorf(ErrCodeInvalidSettings, "invalid game mode: %s", settings.GameMode)
+	}
+
+	// Validate scoring mode, every mode has a scorer
+	if _, ok := scorers[settings.ScoringMode]; !ok {
+		return commandErrorf(ErrCodeInvalidSettings, "invalid scoring mode: %s", settings.ScoringMode)
+	}
+
+	if settings.Password != nil && len(*settings.Password) > MAX_PASSWORD_LENGTH {
+		return commandErrorf(ErrCodeInvalidSettings, "password can't be longer than %d characters", MAX_PASSWORD_LENGTH)
 	}
 
 	settings.CustomWords =
@@ -405,6 +405,7 @@ func TestValidateRoomSettings(t *testing.T) {
 				TotalRounds:        3,
 				WordDifficulty:     WordDifficultyAll,
 				GameMode:           GameModeClassic,
+				ScoringMode:        ScoringModeClassic,
 				WordBank:           WordBankDefault,
 			},
 			wantErr: false,
@@ -505,11 +506,25 @@ func TestValidateRoomSettings(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid game mode: invalid",
 		},
+		{
+			name: "invalid scoring mode",
+			settings: &RoomSettings{
+				PlayerLimit:        6,
+				DrawingTimeAllowed: 90,
+				TotalRounds:        3,
+				WordDifficulty:     WordDifficultyAll,
+				WordBank:           WordBankDefault,
+				GameMode:           GameModeClassic,
+				ScoringMode:        "invalid",
+			},
+			wantErr: true,
+			errMsg:  "invalid scoring mode: invalid",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateRoomSettings(tt.settings)
+			err := validateRoomSettings(tt.settings, DefaultConfig().Rooms)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validateRoomSettings() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -521,6 +536,108 @@ func TestValidateRoomSettings(t *testing.T) {
 	}
 }
 
+func TestValidateStroke(t *testing.T) {
+	tests := []struct {
+		name    string
+		stroke  Stroke
+		want    Stroke
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:   "valid brush stroke",
+			stroke: Stroke{Color: "#00ff7F", Width: 5, Type: "brush", Points: [][]int{{10, 20}}},
+			want:   Stroke{Color: "#00ff7F", Width: 5, Type: "brush", Points: [][]int{{10, 20}}},
+		},
+		{
+			name:   "missing type is a brush",
+			stroke: Stroke{Color: "#000000", Width: 5},
+			want:   Stroke{Color: "#000000", Width: 5, Type: "brush"},
+		},
+		{
+			name:   "points are clamped to the canvas",
+			stroke: Stroke{Color: "#000000", Width: 5, Type: "eraser", Points: [][]int{{-5, 20}, {CANVAS_WIDTH + 1, CANVAS_HEIGHT + 1}}},
+			want:   Stroke{Color: "#000000", Width: 5, Type: "eraser", Points: [][]int{{0, 20}, {CANVAS_WIDTH, CANVAS_HEIGHT}}},
+		},
+		{
+			name:   "fill ignores width",
+			stroke: Stroke{Color: "#000000", Width: 500, Type: "fill", Points: [][]int{{10, 20}}},
+			want:   Stroke{Color: "#000000", Width: 0, Type: "fill", Points: [][]int{{10, 20}}},
+		},
+		{
+			name:    "fill without a point",
+			stroke:  Stroke{Color: "#000000", Type: "fill"},
+			wantErr: true,
+			errMsg:  "fill must have exactly one point",
+		},
+		{
+			name:    "unknown type",
+			stroke:  Stroke{Color: "#000000", Width: 5, Type: "spray"},
+			wantErr: true,
+			errMsg:  "invalid stroke type: spray",
+		},
+		{
+			name:    "width too small",
+			stroke:  Stroke{Color: "#000000", Width: 0, Type: "brush"},
+			wantErr: true,
+			errMsg:  "stroke width must be between 1 and 50",
+		},
+		{
+			name:    "width too large",
+			stroke:  Stroke{Color: "#000000", Width: 51, Type: "brush"},
+			wantErr: true,
+			errMsg:  "stroke width must be between 1 and 50",
+		},
+		{
+			name:    "named color",
+			stroke:  Stroke{Color: "red", Width: 5, Type: "brush"},
+			wantErr: true,
+			errMsg:  "invalid stroke color: red",
+		},
+		{
+			name:    "short hex color",
+			stroke:  Stroke{Color: "#fff", Width: 5, Type: "brush"},
+			wantErr: true,
+			errMsg:  "invalid stroke color: #fff",
+		},
+		{
+			name:    "color with invalid hex digits",
+			stroke:  Stroke{Color: "#00gg00", Width: 5, Type: "brush"},
+			wantErr: true,
+			errMsg:  "invalid stroke color: #00gg00",
+		},
+		{
+			name:    "point with too many coordinates",
+			stroke:  Stroke{Color: "#000000", Width: 5, Type: "brush", Points: [][]int{{1, 2, 3}}},
+			wantErr: true,
+			errMsg:  "stroke points must have 2 coordinates, got 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stroke := tt.stroke
+			err := validateStroke(&stroke)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateStroke() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.errMsg {
+					t.Errorf("validateStroke() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+				if errorCodeOf(err) != ErrCodeInvalidStroke {
+					t.Errorf("validateStroke() error code = %v, want %v", errorCodeOf(err), ErrCodeInvalidStroke)
+				}
+				return
+			}
+			if !reflect.DeepEqual(stroke, tt.want) {
+				t.Errorf("validateStroke() = %+v, want %+v", stroke, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidatePlayerProfile(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Keeps one address from taking over the server, either by opening
+// websocket connections until it runs out of file descriptors or by
+// creating rooms until the room limit is hit.
+//
+// The limits are enforced in middleware before the connection is upgraded,
+// so clients over the limit get a plain 429 response. Behind a load balancer
+// every request comes from the balancer's address, so the client's address
+// is taken from X-Forwarded-For when the request came from a trusted proxy.
+
+const clientIPKey contextKey = "clientIP"
+
+type IPLimitConfig struct {
+	// Websocket connections one address can have open at once, 0 for no limit
+	MaxConnectionsPerIP int `json:"maxConnectionsPerIP"`
+
+	// Rooms one address can create per RoomCreationWindow, 0 for no limit
+	MaxRoomCreationsPerIP int      `json:"maxRoomCreationsPerIP"`
+	RoomCreationWindow    Duration `json:"roomCreationWindow"`
+
+	// Proxies allowed to tell us the client's address
+	TrustedProxies []netip.Prefix `json:"trustedProxies"`
+}
+
+func DefaultIPLimitConfig() IPLimitConfig {
+	return IPLimitConfig{
+		MaxConnectionsPerIP:   10,
+		MaxRoomCreationsPerIP: 5,
+		RoomCreationWindow:    Duration{10 * time.Minute},
+	}
+}
+
+// Parses a comma separated list of addresses and CIDR ranges, ex. "10.0.0.0/8,192.168.1.1"
+func ParseTrustedProxies(s string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if strings.Contains(part, "/") {
+			prefix, err := netip.ParsePrefix(part)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(part)
+		if err != nil {
+			return nil, err
+		}
+		addr = addr.Unmap()
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+type ipLimiter struct {
+	cfg IPLimitConfig
+
+	mu          sync.Mutex
+	connections map[string]int
+	creations   map[string]*creationLimit
+}
+
+type creationLimit struct {
+	limiter *rate.Limiter
+	last    time.Time
+}
+
+func newIPLimiter(cfg IPLimitConfig) *ipLimiter {
+	return &ipLimiter{
+		cfg:         cfg,
+		connections: make(map[string]int),
+		creations:   make(map[string]*creationLimit),
+	}
+}
+
+// Checks if the address is a proxy we trust to forward the client's address
+func (l *ipLimiter) isTrustedProxy(addr netip.Addr) bool {
+	for _, prefix := range l.cfg.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the address of the client that made the request.
+//
+// X-Forwarded-For is only used when the request came from a trusted proxy,
+// otherwise anyone could pick their own address. It's read from the right,
+// since each proxy appends the address it got the request from, and the
+// first address that isn't a trusted proxy is the client.
+func (l *ipLimiter) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	addr = addr.Unmap()
+	if !l.isTrustedProxy(addr) {
+		return addr.String()
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			// Anything left of a malformed entry can't be trusted
+			break
+		}
+		addr = hop.Unmap()
+		if !l.isTrustedProxy(addr) {
+			return addr.String()
+		}
+	}
+
+	// Every hop was a trusted proxy, so the leftmost one is as close
+	// to the client as we can get
+	return addr.String()
+}
+
+// Takes one of the address's connection slots, returning false if it has none left
+func (l *ipLimiter) acquireConnection(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cfg.MaxConnectionsPerIP > 0 && l.connections[ip] >= l.cfg.MaxConnectionsPerIP {
+		return false
+	}
+	l.connections[ip]++
+	return true
+}
+
+func (l *ipLimiter) releaseConnection(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.connections[ip]--
+	if l.connections[ip] <= 0 {
+		delete(l.connections, ip)
+	}
+}
+
+// Counts a room creation against the address, returning false if it
+// already created too many rooms recently
+func (l *ipLimiter) allowRoomCreation(ip string) bool {
+	if l.cfg.MaxRoomCreationsPerIP <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	c, ok := l.creations[ip]
+	if !ok {
+		l.pruneCreations(now)
+		c = &creationLimit{
+			limiter: rate.NewLimiter(
+				rate.Every(l.cfg.RoomCreationWindow.Duration/time.Duration(l.cfg.MaxRoomCreationsPerIP)),
+				l.cfg.MaxRoomCreationsPerIP,
+			),
+		}
+		l.creations[ip] = c
+	}
+	c.last = now
+	return c.limiter.AllowN(now, 1)
+}
+
+// Forgets addresses that haven't created a room for a whole window
+func (l *ipLimiter) pruneCreations(now time.Time) {
+	for ip, c := range l.creations {
+		if now.Sub(c.last) >= l.cfg.RoomCreationWindow.Duration {
+			delete(l.creations, ip)
+		}
+	}
+}
+
+// Adds the client's address to the request context, see clientIP
+func (l *ipLimiter) clientIPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPKey, l.clientIP(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Limits how many websocket connections the client's address can have open.
+// The slot is held until the hijacked connection is closed, or until the
+// handler returns if the connection was never upgraded.
+func (l *ipLimiter) connectionLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := getClientIP(r.Context())
+		if !l.acquireConnection(ip) {
+			http.Error(w, "Too many connections", http.StatusTooManyRequests)
+			return
+		}
+
+		var once sync.Once
+		release := func() { once.Do(func() { l.releaseConnection(ip) }) }
+
+		tw := &trackedResponseWriter{ResponseWriter: w, release: release}
+		next.ServeHTTP(tw, r)
+		if !tw.hijacked {
+			release()
+		}
+	})
+}
+
+// Limits how many rooms the client's address can create
+func (l *ipLimiter) roomCreationLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.allowRoomCreation(getClientIP(r.Context())) {
+			http.Error(w, "Too many rooms created, try again later", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Gets the client's address from the context
+func getClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// Lets us know when a connection taken over by the websocket upgrader is closed
+type trackedResponseWriter struct {
+	http.ResponseWriter
+	release  func()
+	hijacked bool
+}
+
+func (w *trackedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	w.hijacked = true
+	return &trackedConn{Conn: conn, release: w.release}, brw, nil
+}
+
+type trackedConn struct {
+	net.Conn
+	release func()
+}
+
+func (c *trackedConn) Close() error {
+	c.release()
+	return c.Conn.Close()
+}
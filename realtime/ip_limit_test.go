@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestIPLimiter_ClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 192.0.2.1")
+	if err != nil {
+		t.Fatalf("unexpected error parsing proxies: %v", err)
+	}
+	limits := newIPLimiter(IPLimitConfig{TrustedProxies: proxies})
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expectedIP   string
+	}{
+		{name: "direct connection", remoteAddr: "203.0.113.7:51234", expectedIP: "203.0.113.7"},
+		{name: "untrusted clients can't pick their address", remoteAddr: "203.0.113.7:51234", forwardedFor: "198.51.100.1", expectedIP: "203.0.113.7"},
+		{name: "trusted proxy", remoteAddr: "10.1.2.3:443", forwardedFor: "198.51.100.1", expectedIP: "198.51.100.1"},
+		{name: "trusted single address", remoteAddr: "192.0.2.1:443", forwardedFor: "198.51.100.1", expectedIP: "198.51.100.1"},
+		{name: "spoofed entries left of the client are ignored", remoteAddr: "10.1.2.3:443", forwardedFor: "1.1.1.1, 198.51.100.1", expectedIP: "198.51.100.1"},
+		{name: "chain of trusted proxies", remoteAddr: "10.1.2.3:443", forwardedFor: "198.51.100.1, 10.4.5.6", expectedIP: "198.51.100.1"},
+		{name: "malformed entry stops the walk", remoteAddr: "10.1.2.3:443", forwardedFor: "198.51.100.1, nope, 10.4.5.6", expectedIP: "10.4.5.6"},
+		{name: "trusted proxy without the header", remoteAddr: "10.1.2.3:443", expectedIP: "10.1.2.3"},
+		{name: "ipv6", remoteAddr: "[2001:db8::1]:443", expectedIP: "2001:db8::1"},
+		{name: "ipv4 mapped ipv6", remoteAddr: "[::ffff:203.0.113.7]:443", expectedIP: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/join/ABCD", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			if ip := limits.clientIP(r); ip != tt.expectedIP {
+				t.Errorf("expected %s, got %s", tt.expectedIP, ip)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8,192.0.2.1,,2001:db8::/32")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	if len(proxies) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, proxies)
+	}
+	for i := range expected {
+		if proxies[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], proxies[i])
+		}
+	}
+
+	if _, err := ParseTrustedProxies("10.0.0.0/8,not-an-ip"); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestIPLimiter_RoomCreations(t *testing.T) {
+	limits := newIPLimiter(IPLimitConfig{MaxRoomCreationsPerIP: 2, RoomCreationWindow: Duration{time.Hour}})
+
+	for i := 0; i < 2; i++ {
+		if !limits.allowRoomCreation("203.0.113.7") {
+			t.Fatalf("expected room creation %d to be allowed", i+1)
+		}
+	}
+	if limits.allowRoomCreation("203.0.113.7") {
+		t.Error("expected room creations over the limit to be rejected")
+	}
+	if !limits.allowRoomCreation("198.51.100.1") {
+		t.Error("expected other addresses not to be affected")
+	}
+}
+
+func TestIPLimiter_ConnectionsReleasedOnClose(t *testing.T) {
+	limits := newIPLimiter(IPLimitConfig{MaxConnectionsPerIP: 1})
+
+	upgraded := make(chan *websocket.Conn, 1)
+	handler := limits.clientIPMiddleware(limits.connectionLimitMiddleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := UpgradeConnection(newUpgrader(newOriginChecker(DefaultConfig().Server)), w, r)
+			if err != nil {
+				return
+			}
+			upgraded <- conn
+		}),
+	))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	first, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("unexpected error on first connection: %v", err)
+	}
+	defer first.Close()
+	serverConn := <-upgraded
+
+	// The slot is still taken after the handler returned
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err == nil {
+		t.Fatal("expected the second connection to be rejected")
+	}
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a %d response, got %v", http.StatusTooManyRequests, resp)
+	}
+
+	// Closing the connection frees it up
+	serverConn.Close()
+	second, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("expected a new connection once the first was closed, got %v", err)
+	}
+	second.Close()
+	(<-upgraded).Close()
+}
+
+func TestQuickplay_CountsRoomCreations(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IPLimits.MaxRoomCreationsPerIP = 1
+	cfg.IPLimits.RoomCreationWindow = Duration{time.Hour}
+	rm := NewRoomManager(cfg)
+
+	server := httptest.NewServer(*NewServer(rm, cfg))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	// Hosting a private room uses up the address's only room creation
+	host, _, err := websocket.DefaultDialer.Dial(url+"/host", nil)
+	if err != nil {
+		t.Fatalf("unexpected error hosting a room: %v", err)
+	}
+	defer host.Close()
+
+	// There's no public room to join, so quick play would have to start one
+	conn, _, err := websocket.DefaultDialer.Dial(url+"/quickplay", nil)
+	if err != nil {
+		t.Fatalf("unexpected error on quick play: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err = conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Text != ErrTooManyRooms.Error() {
+		t.Fatalf("expected the connection to be closed with %v, got %v", ErrTooManyRooms, err)
+	}
+	if rooms := len(rm.PublicRooms()); rooms != 0 {
+		t.Errorf("expected no public room to be started, got %d", rooms)
+	}
+}
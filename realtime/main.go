@@ -38,23 +38,15 @@ func run(ctx context.Context) error {
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
 	defer cancel()
 
-	port := os.Getenv("PORT")
-	host := os.Getenv("HOST")
-
-	if port == "" {
-		slog.Warn("PORT env not set, defaulting to 8080")
-		port = "8080"
+	cfg, err := LoadConfig(os.Getenv("CONFIG_FILE"), os.Getenv)
+	if err != nil {
+		return err
 	}
-	if host == "" {
-		slog.Warn("HOST env not set, defaulting to localhost")
-		host = "0.0.0.0"
+	if cfg.Server.Environment != "PRODUCTION" {
+		slog.Warn("not running in production, allowing connections from any origin")
 	}
 
-	cfg := &HTTPConfig{
-		Host: host,
-		Port: port,
-	}
-	rm := NewRoomManager()
+	rm := NewRoomManager(cfg)
 	go rm.Run(ctx)
 
 	ServeHTTP(ctx, cfg, rm)
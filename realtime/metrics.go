@@ -0,0 +1,331 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics are served at /metrics in the Prometheus text exposition format,
+// so any Prometheus compatible scraper can collect them.
+//
+// They're package level like the outbox counters since there's one server per
+// process, and they're written from the room, client and scheduler goroutines
+// so everything here is safe for concurrent use.
+
+const (
+	// Label values a vector keeps before folding the rest into "other".
+	// Some labels come from clients, like the command type, and we don't
+	// want a client making up types to grow the metrics forever.
+	MAX_METRIC_LABEL_VALUES = 64
+)
+
+var (
+	roomsActive = newGauge("sketch_rooms_active",
+		"Rooms that are currently open.")
+	roomsCreated = newCounter("sketch_rooms_created_total",
+		"Rooms created since the server started.")
+	clientsConnected = newGauge("sketch_clients_connected",
+		"Websocket clients that are currently connected.")
+	commandsHandled = newCounterVec("sketch_commands_total",
+		"Commands handled by rooms, by command type.", "type")
+	commandErrors = newCounterVec("sketch_command_errors_total",
+		"Commands rejected by rooms, by error code.", "code")
+	commandsDropped = newCounter("sketch_commands_dropped_total",
+		"Commands dropped because the client went over its rate limit.")
+	sendQueueDepth = newHistogram("sketch_client_send_queue_depth",
+		"Events waiting for a client each time its write routine drains the queue.",
+		[]float64{1, 2, 5, 10, 25, 50, 100, 256, 512, 1024})
+	scheduledEventsFired = newCounterVec("sketch_scheduled_events_fired_total",
+		"Scheduled events that fired, by event type.", "type")
+	phaseDuration = newHistogramVec("sketch_phase_duration_seconds",
+		"How long rooms spent in each game phase.", "phase",
+		[]float64{1, 5, 10, 15, 30, 60, 90, 120, 180, 240, 600, 1800})
+
+	// Mirrors of the outbox counters
+	_ = newCounterFunc("sketch_stroke_points_coalesced_total",
+		"Stroke point events merged into batches because a client was behind.",
+		coalescedStrokePoints.Load)
+	_ = newCounterFunc("sketch_slow_consumer_evictions_total",
+		"Clients disconnected for not keeping up with the events sent to them.",
+		slowConsumerEvictions.Load)
+)
+
+// Every metric, in the order they're written
+var (
+	metricsMu sync.Mutex
+	registry  []metric
+)
+
+type metric interface {
+	write(w io.Writer)
+}
+
+func register[M metric](m M) M {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	registry = append(registry, m)
+	return m
+}
+
+// Serves every metric in the text exposition format
+func metricsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w)
+	}
+}
+
+func writeMetrics(w io.Writer) {
+	metricsMu.Lock()
+	metrics := slices.Clone(registry)
+	metricsMu.Unlock()
+
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// A value that only goes up
+type counter struct {
+	name, help string
+	value      atomic.Int64
+}
+
+func newCounter(name, help string) *counter {
+	return register(&counter{name: name, help: help})
+}
+
+func (c *counter) inc() { c.value.Add(1) }
+
+func (c *counter) write(w io.Writer) {
+	writeHeader(w, c.name, c.help, "counter")
+	fmt.Fprintf(w, "%s %d\n", c.name, c.value.Load())
+}
+
+// A counter that's kept somewhere else and read when the metrics are written
+type counterFunc struct {
+	name, help string
+	value      func() int64
+}
+
+func newCounterFunc(name, help string, value func() int64) *counterFunc {
+	return register(&counterFunc{name: name, help: help, value: value})
+}
+
+func (c *counterFunc) write(w io.Writer) {
+	writeHeader(w, c.name, c.help, "counter")
+	fmt.Fprintf(w, "%s %d\n", c.name, c.value())
+}
+
+// A value that goes up and down
+type gauge struct {
+	name, help string
+	value      atomic.Int64
+}
+
+func newGauge(name, help string) *gauge {
+	return register(&gauge{name: name, help: help})
+}
+
+func (g *gauge) inc() { g.value.Add(1) }
+func (g *gauge) dec() { g.value.Add(-1) }
+
+func (g *gauge) write(w io.Writer) {
+	writeHeader(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%s %d\n", g.name, g.value.Load())
+}
+
+// Counters split up by the value of a label
+type counterVec struct {
+	name, help, label string
+
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+func newCounterVec(name, help, label string) *counterVec {
+	return register(&counterVec{name: name, help: help, label: label, values: make(map[string]int64)})
+}
+
+func (c *counterVec) inc(value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[boundedLabel(c.values, value)]++
+}
+
+func (c *counterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, value := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s{%s=%s} %d\n", c.name, c.label, quoteLabel(value), c.values[value])
+	}
+}
+
+// Counts observations in buckets, along with their sum
+type histogram struct {
+	buckets []float64
+	counts  []int64 // one per bucket, plus one for +Inf
+	sum     float64
+	count   int64
+}
+
+func (h *histogram) observe(v float64) {
+	i, _ := slices.BinarySearch(h.buckets, v)
+	h.counts[i]++
+	h.sum += v
+	h.count++
+}
+
+func (h *histogram) write(w io.Writer, name, labels string) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+
+	// Buckets are cumulative in the exposition format
+	var cumulative int64
+	for i, le := range h.buckets {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{%s%sle=\"%s\"} %d\n", name, labels, sep, formatFloat(le), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.count)
+
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels, formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, h.count)
+}
+
+func newBuckets(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]int64, len(buckets)+1)}
+}
+
+type histogramMetric struct {
+	name, help string
+
+	mu sync.Mutex
+	h  *histogram
+}
+
+func newHistogram(name, help string, buckets []float64) *histogramMetric {
+	return register(&histogramMetric{name: name, help: help, h: newBuckets(buckets)})
+}
+
+func (m *histogramMetric) observe(v float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.h.observe(v)
+}
+
+func (m *histogramMetric) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	writeHeader(w, m.name, m.help, "histogram")
+	m.h.write(w, m.name, "")
+}
+
+// Histograms split up by the value of a label
+type histogramVec struct {
+	name, help, label string
+	buckets           []float64
+
+	mu         sync.Mutex
+	histograms map[string]*histogram
+}
+
+func newHistogramVec(name, help, label string, buckets []float64) *histogramVec {
+	return register(&histogramVec{
+		name:       name,
+		help:       help,
+		label:      label,
+		buckets:    buckets,
+		histograms: make(map[string]*histogram),
+	})
+}
+
+func (m *histogramVec) observe(value string, v float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value = boundedLabel(m.histograms, value)
+	h, ok := m.histograms[value]
+	if !ok {
+		h = newBuckets(m.buckets)
+		m.histograms[value] = h
+	}
+	h.observe(v)
+}
+
+func (m *histogramVec) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	writeHeader(w, m.name, m.help, "histogram")
+	for _, value := range sortedKeys(m.histograms) {
+		m.histograms[value].write(w, m.name, m.label+"="+quoteLabel(value))
+	}
+}
+
+// Returns the label value to count under, see MAX_METRIC_LABEL_VALUES
+func boundedLabel[V any](values map[string]V, value string) string {
+	if _, ok := values[value]; ok || len(values) < MAX_METRIC_LABEL_VALUES {
+		return value
+	}
+	return "other"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Label values are quoted with backslashes, quotes and newlines escaped
+func quoteLabel(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Names used for the phase label, ex. "drawing"
+func phaseName(phase int) string {
+	switch phase {
+	case Picking:
+		return "picking"
+	case Drawing:
+		return "drawing"
+	case PostDrawing:
+		return "post_drawing"
+	case GameOver:
+		return "game_over"
+	case Telephone:
+		return "telephone"
+	case TelephoneReveal:
+		return "telephone_reveal"
+	default:
+		return "waiting"
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics_Exposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   metric
+		record   func(m metric)
+		expected string
+	}{
+		{
+			name:   "counter",
+			metric: &counter{name: "test_total", help: "A counter."},
+			record: func(m metric) { m.(*counter).inc(); m.(*counter).inc() },
+			expected: "# HELP test_total A counter.\n" +
+				"# TYPE test_total counter\n" +
+				"test_total 2\n",
+		},
+		{
+			name:   "gauge",
+			metric: &gauge{name: "test_active", help: "A gauge."},
+			record: func(m metric) { m.(*gauge).inc(); m.(*gauge).inc(); m.(*gauge).dec() },
+			expected: "# HELP test_active A gauge.\n" +
+				"# TYPE test_active gauge\n" +
+				"test_active 1\n",
+		},
+		{
+			name:   "labels are sorted and escaped",
+			metric: &counterVec{name: "test_total", help: "A vector.", label: "type", values: map[string]int64{}},
+			record: func(m metric) {
+				m.(*counterVec).inc("b")
+				m.(*counterVec).inc("a\"\\\n")
+				m.(*counterVec).inc("b")
+			},
+			expected: "# HELP test_total A vector.\n" +
+				"# TYPE test_total counter\n" +
+				"test_total{type=\"a\\\"\\\\\\n\"} 1\n" +
+				"test_total{type=\"b\"} 2\n",
+		},
+		{
+			name:   "histogram buckets are cumulative",
+			metric: &histogramMetric{name: "test_depth", help: "A histogram.", h: newBuckets([]float64{1, 5})},
+			record: func(m metric) {
+				for _, v := range []float64{0.5, 1, 3, 10} {
+					m.(*histogramMetric).observe(v)
+				}
+			},
+			expected: "# HELP test_depth A histogram.\n" +
+				"# TYPE test_depth histogram\n" +
+				"test_depth_bucket{le=\"1\"} 2\n" +
+				"test_depth_bucket{le=\"5\"} 3\n" +
+				"test_depth_bucket{le=\"+Inf\"} 4\n" +
+				"test_depth_sum 14.5\n" +
+				"test_depth_count 4\n",
+		},
+		{
+			name: "labelled histogram",
+			metric: &histogramVec{name: "test_seconds", help: "A histogram vector.", label: "phase",
+				buckets: []float64{10}, histograms: map[string]*histogram{}},
+			record: func(m metric) { m.(*histogramVec).observe("drawing", 2.5) },
+			expected: "# HELP test_seconds A histogram vector.\n" +
+				"# TYPE test_seconds histogram\n" +
+				"test_seconds_bucket{phase=\"drawing\",le=\"10\"} 1\n" +
+				"test_seconds_bucket{phase=\"drawing\",le=\"+Inf\"} 1\n" +
+				"test_seconds_sum{phase=\"drawing\"} 2.5\n" +
+				"test_seconds_count{phase=\"drawing\"} 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.record(tt.metric)
+			var b strings.Builder
+			tt.metric.write(&b)
+			if b.String() != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestCounterVec_BoundsLabelValues(t *testing.T) {
+	c := &counterVec{name: "test_total", label: "type", values: map[string]int64{}}
+	for i := 0; i < MAX_METRIC_LABEL_VALUES+10; i++ {
+		c.inc(fmt.Sprintf("type-%d", i))
+	}
+	c.inc("type-0")
+
+	if len(c.values) != MAX_METRIC_LABEL_VALUES+1 {
+		t.Errorf("expected %d label values, got %d", MAX_METRIC_LABEL_VALUES+1, len(c.values))
+	}
+	if c.values["other"] != 10 {
+		t.Errorf("expected values over the limit to be counted as other, got %d", c.values["other"])
+	}
+	if c.values["type-0"] != 2 {
+		t.Errorf("expected values seen before the limit to keep counting, got %d", c.values["type-0"])
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	cfg := DefaultConfig()
+	rm := NewRoomManager(cfg)
+	if _, err := rm.Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := *NewServer(rm, cfg)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("expected the text exposition format, got %q", ct)
+	}
+
+	for _, name := range []string{
+		"sketch_rooms_active",
+		"sketch_rooms_created_total",
+		"sketch_clients_connected",
+		"sketch_commands_total",
+		"sketch_command_errors_total",
+		"sketch_commands_dropped_total",
+		"sketch_client_send_queue_depth",
+		"sketch_scheduled_events_fired_total",
+		"sketch_phase_duration_seconds",
+		"sketch_stroke_points_coalesced_total",
+		"sketch_slow_consumer_evictions_total",
+	} {
+		if !strings.Contains(w.Body.String(), "# TYPE "+name+" ") {
+			t.Errorf("expected %s to be served", name)
+		}
+	}
+}
+
+func TestRoom_RecordsCommandsAndPhases(t *testing.T) {
+	r, players := newTestRoom(GameModeClassic, 1)
+	r.stateEnteredAt = time.Now().Add(-2 * time.Second)
+	p := players[0]
+
+	commands := counterValue(commandsHandled, string(ChatMessageCmd))
+	r.handleClientCommand(&Command{Type: ChatMessageCmd, Player: p, Payload: "hello"})
+	if got := counterValue(commandsHandled, string(ChatMessageCmd)); got != commands+1 {
+		t.Errorf("expected the command to be counted, got %d more", got-commands)
+	}
+
+	errors := counterValue(commandErrors, string(errorCodeOf(ErrInvalidChatMessage)))
+	r.dispatch(&Command{Type: ChatMessageCmd, Player: p, Payload: 42})
+	if got := counterValue(commandErrors, string(errorCodeOf(ErrInvalidChatMessage))); got != errors+1 {
+		t.Errorf("expected the failed command to be counted, got %d more", got-errors)
+	}
+
+	waiting := histogramCount(phaseDuration, "waiting")
+	r.setState(&PickingState{})
+	if got := histogramCount(phaseDuration, "waiting"); got != waiting+1 {
+		t.Errorf("expected the time spent waiting to be observed, got %d more", got-waiting)
+	}
+}
+
+func TestRoom_OnlyTimesEnteredPhases(t *testing.T) {
+	r, _ := newTestRoom(GameModeClassic, 2)
+	r.stateEnteredAt = time.Now()
+
+	// Picking is entered and its Exit moves on to drawing, but going back to
+	// the lobby replaces drawing before it ever starts
+	r.TransitionTo(NewPickingState([]Word{{Value: "apple"}, {Value: "pear"}, {Value: "plum"}}))
+	picking := histogramCount(phaseDuration, "picking")
+	drawing := histogramCount(phaseDuration, "drawing")
+	r.TransitionTo(NewWaitingState())
+
+	if got := histogramCount(phaseDuration, "picking"); got != picking+1 {
+		t.Errorf("expected the time spent picking to be observed once, got %d more", got-picking)
+	}
+	if got := histogramCount(phaseDuration, "drawing"); got != drawing {
+		t.Errorf("expected drawing not to be observed, got %d more", got-drawing)
+	}
+}
+
+func TestRoom_CommandMetricLabels(t *testing.T) {
+	r, players := newTestRoom(GameModeClassic, 1)
+	p := players[0]
+
+	// Made up command types are counted as other, without taking up label values
+	other := counterValue(commandsHandled, "other")
+	for i := 0; i < MAX_METRIC_LABEL_VALUES; i++ {
+		r.handleClientCommand(&Command{Type: CommandType(fmt.Sprintf("made/up-%d", i)), Player: p})
+	}
+	if got := counterValue(commandsHandled, "other"); got != other+MAX_METRIC_LABEL_VALUES {
+		t.Errorf("expected made up commands to be counted as other, got %d more", got-other)
+	}
+	commands := counterValue(commandsHandled, string(VoteSkipWordCmd))
+	r.handleClientCommand(&Command{Type: VoteSkipWordCmd, Player: p})
+	if got := counterValue(commandsHandled, string(VoteSkipWordCmd)); got != commands+1 {
+		t.Errorf("expected real commands to keep their label, got %d more", got-commands)
+	}
+
+	// Commands the room dispatches itself aren't client commands
+	joined := counterValue(commandsHandled, string(PlayerJoinedCmd))
+	other = counterValue(commandsHandled, "other")
+	r.dispatch(&Command{Type: PlayerJoinedCmd, Player: p})
+	if counterValue(commandsHandled, string(PlayerJoinedCmd)) != joined || counterValue(commandsHandled, "other") != other {
+		t.Error("expected commands dispatched by the room not to be counted")
+	}
+}
+
+func counterValue(c *counterVec, value string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[value]
+}
+
+func histogramCount(m *histogramVec, value string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h, ok := m.histograms[value]; ok {
+		return h.count
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The host can remove players who are ruining the game for everyone else.
+//
+// Kicked players can join again with a new link, banned players can't come
+// back to the same room at all, and muted players stay in the game but their
+// chat messages are dropped before anyone sees them. The host can also hand
+// the role over to another player.
+
+// Close reasons sent to players removed by the host
+var (
+	ErrKicked = errors.New("ErrKicked")
+	ErrBanned = errors.New("ErrBanned")
+)
+
+var (
+	ErrPlayerNotFound = &CommandError{ErrCodePlayerNotFound, "player is not in the room"}
+	ErrModerateSelf   = &CommandError{ErrCodeInvalidPayload, "the host can't moderate themselves"}
+	ErrMuted          = &CommandError{ErrCodeMuted, "you have been muted by the host"}
+	ErrSpectatorHost  = &CommandError{ErrCodeWrongRoomRole, "spectators can't be the host"}
+)
+
+// Finds the player a moderation command targets and checks the sender is
+// allowed to moderate them. The payload is the target player's ID.
+func (r *room) moderationTarget(cmd *Command) (*player, error) {
+	if cmd.Player.RoomRole != RoomRoleHost {
+		return nil, ErrWrongRoomRole
+	}
+
+	id, ok := cmd.Payload.(string)
+	if !ok {
+		return nil, ErrInvalidPayload
+	}
+	playerID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	if playerID == cmd.Player.ID {
+		return nil, ErrModerateSelf
+	}
+	target, ok := r.Players[playerID]
+	if !ok {
+		return nil, ErrPlayerNotFound
+	}
+	return target, nil
+}
+
+// Removes a player from the room, they can join again if they want to
+func (r *room) handleKickPlayer(cmd *Command) error {
+	target, err := r.moderationTarget(cmd)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("player kicked", "roomId", r.ID, "playerId", target.ID, "hostId", cmd.Player.ID)
+	r.removePlayer(target, ErrKicked)
+	r.SendSystemMessage(fmt.Sprintf("%s was kicked by the host", target.Username))
+	return nil
+}
+
+// Removes a player from the room and keeps them from coming back,
+// both with their resume token and from the same address
+func (r *room) handleBanPlayer(cmd *Command) error {
+	target, err := r.moderationTarget(cmd)
+	if err != nil {
+		return err
+	}
+
+	r.ban(target, ErrBanned, time.Time{})
+
+	slog.Info("player banned", "roomId", r.ID, "playerId", target.ID, "hostId", cmd.Player.ID)
+	r.removePlayer(target, ErrBanned)
+	r.SendSystemMessage(fmt.Sprintf("%s was banned by the host", target.Username))
+	return nil
+}
+
+// Mutes a player, or unmutes them if they are already muted
+func (r *room) handleMutePlayer(cmd *Command) error {
+	target, err := r.moderationTarget(cmd)
+	if err != nil {
+		return err
+	}
+
+	target.Muted = !target.Muted
+
+	r.broadcast(GameRoleAny,
+		event(SetPlayersEvt, r.Players),
+	)
+	if target.Muted {
+		slog.Info("player muted", "roomId", r.ID, "playerId", target.ID, "hostId", cmd.Player.ID)
+		r.SendSystemMessage(fmt.Sprintf("%s was muted by the host", target.Username))
+	} else {
+		slog.Info("player unmuted", "roomId", r.ID, "playerId", target.ID, "hostId", cmd.Player.ID)
+		r.SendSystemMessage(fmt.Sprintf("%s was unmuted by the host", target.Username))
+	}
+	return nil
+}
+
+// Hands the host role over to another player
+func (r *room) handleTransferHost(cmd *Command) error {
+	target, err := r.moderationTarget(cmd)
+	if err != nil {
+		return err
+	}
+	if target.isSpectator() {
+		return ErrSpectatorHost
+	}
+
+	slog.Info("host transferred", "roomId", r.ID, "playerId", target.ID, "hostId", cmd.Player.ID)
+	r.setHost(target)
+	return nil
+}
+
+// Closes the player's connection with the given reason and removes them
+// from the room right away instead of waiting for their client to shut down.
+func (r *room) removePlayer(p *player, cause error) {
+	if p.client != nil {
+		p.client.close(cause)
+
+		// The disconnect from this client is ignored as stale
+		// since the player is already gone by the time it arrives
+		p.client = nil
+	}
+	r.unregister(p)
+}
+
+// A player the room won't let back in, either for good or until a cooldown ends
+type roomBan struct {
+	cause error     // sent to the player when they try to come back
+	until time.Time // zero for bans that never expire
+}
+
+func (b roomBan) active(now time.Time) bool {
+	return b.cause != nil && (b.until.IsZero() || now.Before(b.until))
+}
+
+// Keeps a player from coming back to the room, both with their
+// resume token and from the same address
+func (r *room) ban(p *player, cause error, until time.Time) {
+	b := roomBan{cause: cause, until: until}
+
+	if r.bannedPlayers == nil {
+		r.bannedPlayers = make(map[uuid.UUID]roomBan)
+	}
+	r.bannedPlayers[p.ID] = b
+
+	if p.remoteIP != "" {
+		if r.bannedIPs == nil {
+			r.bannedIPs = make(map[string]roomBan)
+		}
+		r.bannedIPs[p.remoteIP] = b
+	}
+}
+
+// Checks if a player or anyone from their address was banned from the room,
+// returning why if they were
+func (r *room) checkBanned(playerID uuid.UUID, ip string) error {
+	now := time.Now()
+	if b := r.bannedPlayers[playerID]; b.active(now) {
+		return b.cause
+	}
+	if b := r.bannedIPs[ip]; ip != "" && b.active(now) {
+		return b.cause
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestModerationTarget(t *testing.T) {
+	host := &player{ID: uuid.New(), RoomRole: RoomRoleHost}
+	guest := &player{ID: uuid.New(), RoomRole: RoomRolePlayer}
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID:  host,
+			guest.ID: guest,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		sender        *player
+		payload       interface{}
+		expectedError error
+	}{
+		{name: "host can moderate a player", sender: host, payload: guest.ID.String()},
+		{name: "players can't moderate", sender: guest, payload: host.ID.String(), expectedError: ErrWrongRoomRole},
+		{name: "host can't moderate themselves", sender: host, payload: host.ID.String(), expectedError: ErrModerateSelf},
+		{name: "target must be in the room", sender: host, payload: uuid.New().String(), expectedError: ErrPlayerNotFound},
+		{name: "payload must be a player ID", sender: host, payload: "nope", expectedError: ErrInvalidPayload},
+		{name: "payload must be a string", sender: host, payload: 42, expectedError: ErrInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := room.moderationTarget(&Command{Type: KickPlayerCmd, Player: tt.sender, Payload: tt.payload})
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if err == nil && target != guest {
+				t.Errorf("expected the target to be the guest, got %v", target)
+			}
+		})
+	}
+}
+
+func TestBanPlayer_CantComeBack(t *testing.T) {
+	host := &player{ID: uuid.New(), Username: "host", RoomRole: RoomRoleHost, client: NewClient(nil, nil, nil)}
+	guest := &player{ID: uuid.New(), Username: "guest", RoomRole: RoomRolePlayer, remoteIP: "203.0.113.7"}
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID:  host,
+			guest.ID: guest,
+		},
+		Settings:     RoomSettings{PlayerLimit: 6},
+		ChatMessages: make([]ChatMessage, 0),
+		drawingQueue: []uuid.UUID{host.ID, guest.ID},
+		scheduler:    NewGameScheduler(),
+		currentState: NewWaitingState(),
+	}
+
+	room.dispatch(&Command{Type: BanPlayerCmd, Player: host, Payload: guest.ID.String()})
+	if _, ok := room.Players[guest.ID]; ok {
+		t.Fatal("expected the banned player to be removed from the room")
+	}
+	for _, id := range room.drawingQueue {
+		if id == guest.ID {
+			t.Error("expected the banned player to be removed from the drawing queue")
+		}
+	}
+
+	// Resuming their old session doesn't work
+	err := room.resume(context.Background(), &resumeAttempt{playerID: guest.ID})
+	if !errors.Is(err, ErrBanned) {
+		t.Errorf("expected resuming a banned player to fail with %v, got %v", ErrBanned, err)
+	}
+
+	// Neither does joining again as a new player from the same address
+	err = room.register(context.Background(), &player{ID: uuid.New(), RoomRole: RoomRolePlayer, remoteIP: "203.0.113.7"})
+	if !errors.Is(err, ErrBanned) {
+		t.Errorf("expected joining from a banned address to fail with %v, got %v", ErrBanned, err)
+	}
+}
+
+func TestKickPlayer_CanComeBack(t *testing.T) {
+	host := &player{ID: uuid.New(), Username: "host", RoomRole: RoomRoleHost, client: NewClient(nil, nil, nil)}
+	guest := &player{ID: uuid.New(), Username: "guest", RoomRole: RoomRolePlayer, remoteIP: "203.0.113.7"}
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID:  host,
+			guest.ID: guest,
+		},
+		ChatMessages: make([]ChatMessage, 0),
+		drawingQueue: []uuid.UUID{host.ID, guest.ID},
+		scheduler:    NewGameScheduler(),
+		currentState: NewWaitingState(),
+	}
+
+	room.dispatch(&Command{Type: KickPlayerCmd, Player: host, Payload: guest.ID.String()})
+	if _, ok := room.Players[guest.ID]; ok {
+		t.Fatal("expected the kicked player to be removed from the room")
+	}
+	if room.checkBanned(guest.ID, guest.remoteIP) != nil {
+		t.Error("expected a kicked player to be allowed back in")
+	}
+	if isResumableCause(ErrKicked) {
+		t.Error("expected kicked players not to be held for resuming")
+	}
+}
+
+func TestMutePlayer_DropsChat(t *testing.T) {
+	host := &player{ID: uuid.New(), Username: "host", RoomRole: RoomRoleHost, client: NewClient(nil, nil, nil)}
+	guest := &player{ID: uuid.New(), Username: "guest", RoomRole: RoomRolePlayer, client: NewClient(nil, nil, nil)}
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID:  host,
+			guest.ID: guest,
+		},
+		ChatMessages: make([]ChatMessage, 0),
+		currentState: NewWaitingState(),
+	}
+
+	room.dispatch(&Command{Type: MutePlayerCmd, Player: host, Payload: guest.ID.String()})
+	if !guest.Muted {
+		t.Fatal("expected the guest to be muted")
+	}
+
+	before := len(room.ChatMessages)
+	drainEvents(guest.client)
+	room.dispatch(&Command{Type: ChatMessageCmd, Player: guest, Payload: "hello"})
+	if len(room.ChatMessages) != before {
+		t.Error("expected chat from a muted player to be dropped")
+	}
+	if events := drainEvents(guest.client); len(events) == 0 || events[0].Type != CommandErrorEvt {
+		t.Error("expected the muted player to be told their message was dropped")
+	}
+
+	// Muting again unmutes them
+	room.dispatch(&Command{Type: MutePlayerCmd, Player: host, Payload: guest.ID.String()})
+	room.dispatch(&Command{Type: ChatMessageCmd, Player: guest, Payload: "hello again"})
+	if guest.Muted {
+		t.Fatal("expected muting a muted player to unmute them")
+	}
+	if last := room.ChatMessages[len(room.ChatMessages)-1]; last.PlayerID != guest.ID {
+		t.Error("expected the unmuted player's chat to go through")
+	}
+}
+
+func TestTransferHost(t *testing.T) {
+	host := &player{ID: uuid.New(), Username: "host", RoomRole: RoomRoleHost, client: NewClient(nil, nil, nil)}
+	guest := &player{ID: uuid.New(), Username: "guest", RoomRole: RoomRolePlayer, client: NewClient(nil, nil, nil)}
+	watcher := &player{ID: uuid.New(), Username: "watcher", RoomRole: RoomRoleSpectator, client: NewClient(nil, nil, nil)}
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			host.ID:    host,
+			guest.ID:   guest,
+			watcher.ID: watcher,
+		},
+		ChatMessages: make([]ChatMessage, 0),
+		currentState: NewWaitingState(),
+	}
+
+	err := room.handleTransferHost(&Command{Type: TransferHostCmd, Player: host, Payload: watcher.ID.String()})
+	if !errors.Is(err, ErrSpectatorHost) {
+		t.Fatalf("expected handing the host role to a spectator to fail with %v, got %v", ErrSpectatorHost, err)
+	}
+
+	drainEvents(guest.client)
+	if err := room.handleTransferHost(&Command{Type: TransferHostCmd, Player: host, Payload: guest.ID.String()}); err != nil {
+		t.Fatalf("unexpected error transferring host: %v", err)
+	}
+	if host.RoomRole != RoomRolePlayer || guest.RoomRole != RoomRoleHost {
+		t.Fatalf("expected the guest to be the only host, got host=%v guest=%v", host.RoomRole, guest.RoomRole)
+	}
+
+	events := drainEvents(guest.client)
+	if len(events) == 0 || events[0].Type != HostChangedEvt {
+		t.Fatal("expected players to be told the host changed")
+	}
+	if change := events[0].Payload.(HostChange); change.PreviousHostID != host.ID || change.HostID != guest.ID {
+		t.Errorf("expected host change from %v to %v, got %+v", host.ID, guest.ID, change)
+	}
+
+	// The old host can't transfer it back anymore
+	err = room.handleTransferHost(&Command{Type: TransferHostCmd, Player: host, Payload: guest.ID.String()})
+	if !errors.Is(err, ErrWrongRoomRole) {
+		t.Errorf("expected the old host to lose host permissions, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Number of queued events a client can have before it's considered behind
+	MAX_QUEUED_EVENTS = 256
+
+	// Number of queued events at which a client is disconnected right away
+	MAX_QUEUED_EVENTS_HARD = MAX_QUEUED_EVENTS * 4
+
+	// How long a client can stay behind before it's disconnected
+	SLOW_CLIENT_TIMEOUT = 5 * time.Second
+)
+
+var ErrSlowConsumer = errors.New("ErrSlowConsumer")
+
+// Counters for how often clients fall behind
+var (
+	// Stroke point events merged into batches because a client was behind
+	coalescedStrokePoints atomic.Int64
+
+	// Clients disconnected for not keeping up with the events sent to them
+	slowConsumerEvictions atomic.Int64
+)
+
+// outbox is a bounded queue of frames waiting to be written to a client.
+//
+// The room pushes events from its goroutine and the client's write routine
+// drains them from its own. Pushing never blocks, so one client on a bad
+// connection can't stall the room for everyone else. When a client falls
+// behind, queued stroke points are merged into batches, and if that isn't
+// enough and the client stays behind, it gets evicted.
+type outbox struct {
+	mu     sync.Mutex
+	frames []*frame
+
+	// Number of events across all queued frames
+	queued int
+
+	// When the client went over MAX_QUEUED_EVENTS, zero if it's keeping up
+	behindSince time.Time
+
+	// Set once the client has been evicted, nothing else gets queued after that
+	evicted bool
+
+	// Signals the write routine that there are events to drain
+	ready chan struct{}
+}
+
+func newOutbox() *outbox {
+	return &outbox{
+		frames: make([]*frame, 0),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// Queues a frame for the client without blocking.
+//
+// Returns false if the client can't keep up and should be disconnected.
+// This only happens once, frames pushed after that are dropped.
+func (o *outbox) push(now time.Time, f *frame) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.evicted {
+		return true
+	}
+
+	o.frames = append(o.frames, f)
+	o.queued += len(f.events)
+
+	if o.queued > MAX_QUEUED_EVENTS {
+		o.coalesce()
+	}
+
+	if o.queued <= MAX_QUEUED_EVENTS {
+		o.behindSince = time.Time{}
+	} else if o.behindSince.IsZero() {
+		o.behindSince = now
+	}
+
+	if o.queued > MAX_QUEUED_EVENTS_HARD ||
+		(!o.behindSince.IsZero() && now.Sub(o.behindSince) > SLOW_CLIENT_TIMEOUT) {
+		o.evicted = true
+		o.frames = nil
+		o.queued = 0
+		slowConsumerEvictions.Add(1)
+		return false
+	}
+
+	// Wake up the write routine if it isn't already awake
+	select {
+	case o.ready <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Takes all queued frames for the write routine.
+func (o *outbox) drain() []*frame {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	frames := o.frames
+	o.frames = make([]*frame, 0, len(frames))
+	o.queued = 0
+	o.behindSince = time.Time{}
+	return frames
+}
+
+// Merges runs of queued stroke point events into single batch events
+// and replaces the queue with one frame holding the result.
+//
+// Frames and events are shared by every player in a broadcast, so we
+// build new ones rather than modifying the queued ones. The new frame
+// is only encoded for this client, which is fine since this is the slow path.
+func (o *outbox) coalesce() {
+	merged := make([]*Event, 0, o.queued)
+	for _, f := range o.frames {
+		merged = coalesceStrokePoints(merged, f.events)
+	}
+	o.frames = []*frame{newFrame(merged...)}
+	o.queued = len(merged)
+}
+
+// Appends events to merged, folding stroke point events into the
+// batch at the end of merged when there is one.
+func coalesceStrokePoints(merged []*Event, events []*Event) []*Event {
+	for _, evt := range events {
+		points, ok := strokePoints(evt)
+		if !ok {
+			merged = append(merged, evt)
+			continue
+		}
+
+		// Extend the batch if the previous event is one we created
+		if last := len(merged) - 1; last >= 0 && merged[last].Type == AddStrokePointsEvt {
+			if prev, ok := strokePoints(merged[last]); ok {
+				batch := append(prev[:len(prev):len(prev)], points...)
+				merged[last] = event(AddStrokePointsEvt, batch)
+				coalescedStrokePoints.Add(1)
+				continue
+			}
+		}
+
+		merged = append(merged, event(AddStrokePointsEvt, points))
+	}
+	return merged
+}
+
+// Returns the points carried by a stroke point event
+func strokePoints(evt *Event) ([][]int, bool) {
+	switch evt.Type {
+	case AddStrokePointEvt:
+		point, ok := evt.Payload.([]int)
+		return [][]int{point}, ok
+	case AddStrokePointsEvt:
+		points, ok := evt.Payload.([][]int)
+		return points, ok
+	}
+	return nil, false
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Takes all events queued for a client
+func drainEvents(c *client) []*Event {
+	events := make([]*Event, 0)
+	for _, f := range c.outbox.drain() {
+		events = append(events, f.events...)
+	}
+	return events
+}
+
+func TestOutbox_CoalescesStrokePointsWhenBehind(t *testing.T) {
+	o := newOutbox()
+	now := time.Now()
+
+	// Fill the queue to the limit with stroke points, then go over with a stroke
+	o.push(now, newFrame(event(AddStrokeEvt, Stroke{Color: "#000000"})))
+	for i := 0; i < MAX_QUEUED_EVENTS; i++ {
+		o.push(now, newFrame(event(AddStrokePointEvt, []int{i, i})))
+	}
+	o.push(now, newFrame(event(UndoStrokeEvt, nil)))
+
+	events := make([]*Event, 0)
+	for _, f := range o.drain() {
+		events = append(events, f.events...)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events after coalescing, got %d", len(events))
+	}
+	if events[0].Type != AddStrokeEvt || events[2].Type != UndoStrokeEvt {
+		t.Errorf("expected non-point events to keep their order, got %s and %s", events[0].Type, events[2].Type)
+	}
+
+	points, ok := events[1].Payload.([][]int)
+	if events[1].Type != AddStrokePointsEvt || !ok {
+		t.Fatalf("expected a stroke points batch, got %s", events[1].Type)
+	}
+	if len(points) != MAX_QUEUED_EVENTS {
+		t.Fatalf("expected %d points in the batch, got %d", MAX_QUEUED_EVENTS, len(points))
+	}
+	for i, p := range points {
+		if !reflect.DeepEqual(p, []int{i, i}) {
+			t.Fatalf("expected point %d to be [%d %d], got %v", i, i, i, p)
+		}
+	}
+}
+
+func TestOutbox_EvictsClientsThatStayBehind(t *testing.T) {
+	o := newOutbox()
+	now := time.Now()
+
+	// Events that can't be coalesced keep the client over the limit
+	for i := 0; i <= MAX_QUEUED_EVENTS; i++ {
+		if !o.push(now, newFrame(event(UndoStrokeEvt, nil))) {
+			t.Fatal("client evicted before falling behind")
+		}
+	}
+
+	// Still within the grace period
+	if !o.push(now.Add(SLOW_CLIENT_TIMEOUT/2), newFrame(event(UndoStrokeEvt, nil))) {
+		t.Fatal("client evicted before the timeout")
+	}
+
+	before := slowConsumerEvictions.Load()
+	if o.push(now.Add(SLOW_CLIENT_TIMEOUT+time.Second), newFrame(event(UndoStrokeEvt, nil))) {
+		t.Fatal("expected client to be evicted after the timeout")
+	}
+	if slowConsumerEvictions.Load() != before+1 {
+		t.Error("expected eviction to be counted")
+	}
+
+	// Only the first push after falling behind reports the eviction
+	if !o.push(now.Add(SLOW_CLIENT_TIMEOUT*2), newFrame(event(UndoStrokeEvt, nil))) {
+		t.Error("expected later pushes to be dropped quietly")
+	}
+	if len(o.drain()) != 0 {
+		t.Error("expected queue to be emptied on eviction")
+	}
+}
+
+func TestOutbox_DrainingCatchesUp(t *testing.T) {
+	o := newOutbox()
+	now := time.Now()
+
+	for i := 0; i <= MAX_QUEUED_EVENTS; i++ {
+		o.push(now, newFrame(event(UndoStrokeEvt, nil)))
+	}
+	o.drain()
+
+	// The client caught up, so the timeout starts over
+	if !o.push(now.Add(SLOW_CLIENT_TIMEOUT*2), newFrame(event(UndoStrokeEvt, nil))) {
+		t.Error("expected client that caught up to stay connected")
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// The host can lock a room with a password so only players they share it
+// with can get in, room codes are short enough to be guessed.
+//
+// Players send the password with ?password=<password> when they join through
+// /join/{code} or /spectate/{code}, and it's checked before they are connected
+// to the room. Players resuming a session already got in, so they don't need it.
+//
+// Only a salted hash of the password is kept and it's never sent to clients.
+// Failed attempts are rate limited per address so the password can't be
+// brute-forced either.
+
+const (
+	MAX_PASSWORD_LENGTH = 64
+
+	// How many wrong passwords an address can send before it has to slow down
+	PASSWORD_ATTEMPT_BURST = 5
+
+	// How long it takes for an address to earn back one failed attempt
+	PASSWORD_ATTEMPT_INTERVAL = 10 * time.Second
+)
+
+// Close reasons sent to players who couldn't get past the password
+var (
+	ErrPasswordRequired        = errors.New("ErrPasswordRequired")
+	ErrWrongPassword           = errors.New("ErrWrongPassword")
+	ErrTooManyPasswordAttempts = errors.New("ErrTooManyPasswordAttempts")
+)
+
+// A room's password and the failed attempts to enter it.
+//
+// The room's goroutine sets the password, but it's checked from the
+// HTTP handler before the player is connected, so it has its own lock.
+type roomPassword struct {
+	mu sync.Mutex
+
+	salt []byte
+	hash []byte // nil when the room doesn't have a password
+
+	// Failed attempts by address
+	failures map[string]*passwordFailures
+}
+
+type passwordFailures struct {
+	limiter *rate.Limiter
+	last    time.Time
+}
+
+func hashPassword(salt []byte, password string) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(password))
+	return h.Sum(nil)
+}
+
+// Sets the password, an empty password removes it
+func (p *roomPassword) set(password string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if password == "" {
+		p.salt, p.hash = nil, nil
+		return
+	}
+
+	// A new salt every time so the same password doesn't hash the same in different rooms
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		// This should never happen
+		panic(err)
+	}
+	p.salt = salt
+	p.hash = hashPassword(salt, password)
+
+	// Attempts against the old password don't count against the new one
+	p.failures = nil
+}
+
+func (p *roomPassword) isSet() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hash != nil
+}
+
+// Checks the password a player sent from the given address
+func (p *roomPassword) check(ip, password string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.hash == nil {
+		return nil
+	}
+	if password == "" {
+		return ErrPasswordRequired
+	}
+
+	now := time.Now()
+	f := p.failures[ip]
+	if f != nil && f.limiter.TokensAt(now) < 1 {
+		return ErrTooManyPasswordAttempts
+	}
+
+	if subtle.ConstantTimeCompare(hashPassword(p.salt, password), p.hash) == 1 {
+		return nil
+	}
+
+	if f == nil {
+		p.pruneFailures(now)
+		if p.failures == nil {
+			p.failures = make(map[string]*passwordFailures)
+		}
+		f = &passwordFailures{
+			limiter: rate.NewLimiter(rate.Every(PASSWORD_ATTEMPT_INTERVAL), PASSWORD_ATTEMPT_BURST),
+		}
+		p.failures[ip] = f
+	}
+	f.limiter.AllowN(now, 1)
+	f.last = now
+	return ErrWrongPassword
+}
+
+// Forgets addresses that have earned back all of their attempts
+func (p *roomPassword) pruneFailures(now time.Time) {
+	for ip, f := range p.failures {
+		if now.Sub(f.last) >= PASSWORD_ATTEMPT_INTERVAL*PASSWORD_ATTEMPT_BURST {
+			delete(p.failures, ip)
+		}
+	}
+}
+
+// Checks the password a player sent when joining the room
+func (r *room) CheckPassword(ip, password string) error {
+	return r.password.check(ip, password)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRoomPassword_Check(t *testing.T) {
+	var password roomPassword
+
+	if err := password.check("203.0.113.7", ""); err != nil {
+		t.Fatalf("expected anyone to get into a room without a password, got %v", err)
+	}
+
+	password.set("hunter2")
+
+	tests := []struct {
+		name          string
+		password      string
+		expectedError error
+	}{
+		{name: "right password", password: "hunter2"},
+		{name: "no password", password: "", expectedError: ErrPasswordRequired},
+		{name: "wrong password", password: "hunter3", expectedError: ErrWrongPassword},
+		{name: "passwords are case sensitive", password: "HUNTER2", expectedError: ErrWrongPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := password.check("203.0.113.7", tt.password)
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestRoomPassword_RateLimitsFailures(t *testing.T) {
+	var password roomPassword
+	password.set("hunter2")
+
+	for i := 0; i < PASSWORD_ATTEMPT_BURST; i++ {
+		if err := password.check("203.0.113.7", "guess"); !errors.Is(err, ErrWrongPassword) {
+			t.Fatalf("attempt %d: expected %v, got %v", i+1, ErrWrongPassword, err)
+		}
+	}
+
+	// Even the right password is turned away until the address slows down
+	if err := password.check("203.0.113.7", "hunter2"); !errors.Is(err, ErrTooManyPasswordAttempts) {
+		t.Errorf("expected %v after too many failures, got %v", ErrTooManyPasswordAttempts, err)
+	}
+
+	// Other addresses aren't affected
+	if err := password.check("198.51.100.1", "hunter2"); err != nil {
+		t.Errorf("expected another address to get in, got %v", err)
+	}
+
+	// A new password starts fresh
+	password.set("correct horse")
+	if err := password.check("203.0.113.7", "correct horse"); err != nil {
+		t.Errorf("expected failures to be forgotten when the password changes, got %v", err)
+	}
+}
+
+func TestWaitingState_HandleCommand_ChangeRoomSettings_Password(t *testing.T) {
+	host := &player{ID: uuid.New(), RoomRole: RoomRoleHost, client: NewClient(nil, nil, nil)}
+	room := &room{
+		Players:      map[uuid.UUID]*player{host.ID: host},
+		currentState: NewWaitingState(),
+		scheduler:    NewGameScheduler(),
+	}
+
+	// Settings arrive as decoded JSON from the client
+	settings := func(extra map[string]interface{}) map[string]interface{} {
+		payload := map[string]interface{}{
+			"playerLimit":        6,
+			"drawingTimeAllowed": 90,
+			"totalRounds":        3,
+			"wordDifficulty":     string(WordDifficultyAll),
+			"gameMode":           string(GameModeClassic),
+			"wordBank":           string(WordBankDefault),
+		}
+		for k, v := range extra {
+			payload[k] = v
+		}
+		return payload
+	}
+	change := func(payload map[string]interface{}) {
+		t.Helper()
+		err := room.currentState.HandleCommand(room, &Command{Type: ChangeRoomSettingsCmd, Player: host, Payload: payload})
+		if err != nil {
+			t.Fatalf("unexpected error changing settings: %v", err)
+		}
+	}
+
+	change(settings(map[string]interface{}{"password": "hunter2"}))
+	if !room.Settings.HasPassword {
+		t.Fatal("expected the room to have a password")
+	}
+	if room.Settings.Password != nil {
+		t.Error("expected the password not to be kept in the settings sent to clients")
+	}
+	if err := room.CheckPassword("203.0.113.7", "hunter2"); err != nil {
+		t.Errorf("expected the password to work, got %v", err)
+	}
+
+	// Changing other settings keeps the password
+	change(settings(map[string]interface{}{"totalRounds": 5}))
+	if !room.Settings.HasPassword {
+		t.Error("expected the password to be kept when it isn't sent")
+	}
+
+	// An empty password removes it
+	change(settings(map[string]interface{}{"password": ""}))
+	if room.Settings.HasPassword {
+		t.Error("expected an empty password to remove it")
+	}
+	if err := room.CheckPassword("203.0.113.7", ""); err != nil {
+		t.Errorf("expected anyone to get in once the password is removed, got %v", err)
+	}
+}
@@ -19,36 +19,41 @@ func NewPickingState(wordOptions []Word) RoomState {
 	return &PickingState{
 		wordOptions:  wordOptions,
 		selectedWord: nil,
-		endsAt:       time.Now().Add(time.Second * 15),
 	}
 }
 
 // Enter is called when the game enters the picking state
 func (state *PickingState) Enter(room *room) {
-	nextDrawer := room.getNextDrawingPlayer()
+	state.endsAt = time.Now().Add(room.config().Game.PickingPhaseDuration.Duration)
+
+	drawers := room.getNextDrawingPlayers()
 
 	// Handle end of round or new game scenarios
-	if nextDrawer == nil {
+	if len(drawers) == 0 {
 		slog.Debug("queue is empty, checking if we're at the end of a round")
-		// It's the last round, return to waiting state
+		// It's the last round, show the final standings
 		if room.CurrentRound >= room.Settings.TotalRounds {
-			slog.Debug("it's the last round, returning to waiting state")
-			room.TransitionTo(NewWaitingState())
+			slog.Debug("it's the last round, ending the game")
+			// Nobody is picking, so skip Exit rather than picking a word for the last drawer
+			room.setState(NewGameOverState())
+			room.enterState()
 			return
 		}
 
 		// Otherwise, increment the round and refill the queue
 		room.CurrentRound++
 		room.fillDrawingQueue()
-		nextDrawer = room.getNextDrawingPlayer()
+		drawers = room.getNextDrawingPlayers()
 
-		if nextDrawer == nil {
+		if len(drawers) == 0 {
 			return
 		}
 	}
 
-	// Set up the new drawer and send them the word options
-	room.currentDrawer = nextDrawer
+	// Set up the new drawers and send the word options to the one picking.
+	// The others in a relay game get the drawing role once drawing starts.
+	room.currentDrawers = drawers
+	nextDrawer := room.leadDrawer()
 	nextDrawer.GameRole = GameRoleDrawing
 	nextDrawer.Send(
 		event(SetWordOptionsEvt, state.wordOptions),
@@ -81,8 +86,8 @@ func (state *PickingState) Exit(room *room) {
 		randomIndex := rand.Intn(len(state.wordOptions) - 1)
 		state.selectedWord = &state.wordOptions[randomIndex]
 
-		// Notify all players of the chosen word
-		room.currentDrawer.Send(
+		// Notify the drawer of the chosen word
+		room.leadDrawer().Send(
 			event(SetSelectedWordEvt, state.selectedWord),
 		)
 	}
@@ -94,16 +99,16 @@ func (state *PickingState) Exit(room *room) {
 func SafeExtractWord(payload interface{}) (*Word, error) {
 	wordMap, ok := payload.(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("invalid payload format: expected map[string]interface{}")
+		return nil, commandErrorf(ErrCodeInvalidPayload, "invalid payload format: expected map[string]interface{}")
 	}
 
 	value, ok := wordMap["value"].(string)
 	if !ok {
-		return nil, fmt.Errorf("invalid word format: 'value' field must be a string")
+		return nil, commandErrorf(ErrCodeInvalidPayload, "invalid word format: 'value' field must be a string")
 	}
 
 	if value == "" {
-		return nil, fmt.Errorf("invalid word: value cannot be empty")
+		return nil, commandErrorf(ErrCodeInvalidWord, "invalid word: value cannot be empty")
 	}
 
 	return &Word{Value: value}, nil
@@ -134,7 +139,7 @@ func (state *PickingState) handleWordSelection(room *room, cmd *Command) error {
 	}
 	if foundDrawingWord == nil {
 		slog.Error("selected word is not a valid option", "word", selectedWord.Value)
-		return fmt.Errorf("selected word is not a valid option")
+		return commandErrorf(ErrCodeInvalidWord, "selected word is not a valid option")
 	}
 
 	state.selectedWord = foundDrawingWord
@@ -148,10 +153,22 @@ func (state *PickingState) handleWordSelection(room *room, cmd *Command) error {
 
 // handlePlayerLeft handles when a player leaves during the picking state
 func (state *PickingState) handlePlayerLeft(room *room, cmd *Command) error {
-	// If the drawer leaves, restart the picking state
-	if cmd.Player.GameRole == GameRoleDrawing {
-		room.TransitionTo(NewPickingState(state.wordOptions))
+	if cmd.Player != room.leadDrawer() {
+		return nil
+	}
+
+	// If the drawer leaves in a relay game the next drawer picks instead
+	if len(room.currentDrawers) > 1 {
+		room.removeDrawer(cmd.Player)
+		next := room.leadDrawer()
+		next.GameRole = GameRoleDrawing
+		next.Send(event(SetWordOptionsEvt, state.wordOptions))
+		room.broadcast(GameRoleAny, event(SetPlayersEvt, room.Players))
+		return nil
 	}
+
+	// Otherwise restart the picking state
+	room.TransitionTo(NewPickingState(state.wordOptions))
 	return nil
 }
 
@@ -162,6 +179,13 @@ func (state *PickingState) handlePlayerJoined(room *room, cmd *Command) error {
 		event(SetCurrentStateEvt, Picking),
 		event(SetTimerEvt, state.endsAt.UTC()),
 	)
+
+	// The drawer is resuming their session, send them their options again
+	if cmd.Player == room.leadDrawer() && state.selectedWord == nil {
+		cmd.Player.Send(
+			event(SetWordOptionsEvt, state.wordOptions),
+		)
+	}
 	return nil
 }
 
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,9 +11,10 @@ import (
 type RoomRole string
 
 const (
-	RoomRoleHost   RoomRole = "host"
-	RoomRolePlayer RoomRole = "player"
-	RoomRoleAny    RoomRole = "any"
+	RoomRoleHost      RoomRole = "host"
+	RoomRolePlayer    RoomRole = "player"
+	RoomRoleSpectator RoomRole = "spectator"
+	RoomRoleAny       RoomRole = "any"
 )
 
 type GameRole string
@@ -51,8 +54,20 @@ type player struct {
 	GameRole          GameRole      `json:"gameRole"`
 	Score             int           `json:"score"`
 	Streak            int           `json:"streak"`
+	SittingOut        bool          `json:"sittingOut"`
+	Muted             bool          `json:"muted"`
+	Team              Team          `json:"team,omitempty"`
 	lastInteractionAt time.Time
+	disconnectedAt    time.Time
+	joinedAt          time.Time
 	client            *client
+
+	// Address the player connected from, used to enforce bans
+	remoteIP string
+
+	// Stats for the final standings, reset when a new game starts
+	wordsDrawn     []string
+	correctGuesses int
 }
 
 func NewPlayer(role RoomRole) *player {
@@ -68,8 +83,54 @@ func NewPlayer(role RoomRole) *player {
 	}
 }
 
+// Checks if the player is taking part in the current game.
+//
+// Spectators and players sitting out still get every event,
+// but they aren't dealt drawing turns and can't score by guessing.
+func (p *player) isActive() bool {
+	return !p.SittingOut && !p.isSpectator()
+}
+
+// Spectators only watch, they don't take a seat in the room
+func (p *player) isSpectator() bool {
+	return p.RoomRole == RoomRoleSpectator
+}
+
+// Sorts players by when they joined, so anything handed out in that
+// order comes out the same no matter how the players map is iterated
+func byJoinOrder(a, b *player) int {
+	if c := a.joinedAt.Compare(b.joinedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
 // Passes messages to the player's client.
+// Messages sent while the player is disconnected are dropped,
+// they get the full state again when they resume.
+//
+// This never blocks, clients that can't keep up are disconnected instead.
 func (p *player) Send(events ...*Event) {
-	eventList := append([]*Event{}, events...)
-	p.client.send <- eventList
+	if len(events) == 0 {
+		return
+	}
+	p.sendFrame(newFrame(events...))
+}
+
+// Passes an already built frame to the player's client.
+// Used by broadcasts so every recipient shares the same encoded frame.
+func (p *player) sendFrame(f *frame) {
+	if !p.isConnected() {
+		return
+	}
+	if !p.client.outbox.push(time.Now(), f) {
+		slog.Warn("client is not keeping up, disconnecting", "playerId", p.ID)
+		p.client.close(ErrSlowConsumer)
+	}
+}
+
+// Checks if the player currently has a live client.
+// Players without one are waiting to resume their session.
+func (p *player) isConnected() bool {
+	return p.client != nil
 }
@@ -8,27 +8,26 @@ import (
 
 // PostDrawingState represents the state after a drawing round has completed
 type PostDrawingState struct {
-	pointsAwarded map[uuid.UUID]int // Maps player IDs to points they earned this round
-	endsAt        time.Time         // When this state should automatically transition
+	pointsAwarded     map[uuid.UUID]int // Maps player IDs to points they earned this round
+	teamPointsAwarded map[Team]int      // Points each team earned this round, only set in team games
+	endsAt            time.Time         // When this state should automatically transition
 }
 
 // NewPostDrawingState creates a new post-drawing state with the given points distribution
 func NewPostDrawingState(pointsAwarded map[uuid.UUID]int) RoomState {
 	return &PostDrawingState{
 		pointsAwarded: pointsAwarded,
-		endsAt:        time.Now().Add(time.Second * 5), // Show results for 5 seconds
 	}
 }
 
 // Enter is called when transitioning into the post-drawing state
 func (state *PostDrawingState) Enter(room *room) {
-	// If its the last phase, we increase the duration to allow
-	// players to see the correct word and scoreboard for longer.
-	if room.CurrentRound >= room.Settings.TotalRounds && len(room.drawingQueue) == 0 {
-		state.endsAt = time.Now().Add(time.Second * 15)
+	if room.isTeamGame() {
+		state.teamPointsAwarded = room.teamPointsAwarded(state.pointsAwarded)
 	}
 
-	// Schedule automatic transition after 5 seconds
+	// Show the results for a few seconds before moving on
+	state.endsAt = time.Now().Add(room.config().Game.PostDrawingPhaseDuration.Duration)
 	room.scheduler.addEvent(ScheduledStateChange, state.endsAt, func() {
 		room.Transition()
 	})
@@ -36,6 +35,7 @@ func (state *PostDrawingState) Enter(room *room) {
 	// Broadcast the results to all players
 	room.broadcast(GameRoleAny,
 		event(SetPointsAwardedEvt, state.pointsAwarded),
+		event(SetTeamPointsAwardedEvt, state.teamPointsAwarded),
 		event(SetCurrentStateEvt, PostDrawing),
 		event(SetTimerEvt, state.endsAt.UTC()),
 	)
@@ -74,6 +74,8 @@ func (state *PostDrawingState) HandleCommand(room *room, cmd *Command) error {
 func (state *PostDrawingState) handlePlayerJoined(cmd *Command) {
 	// Send the current game state to the new player
 	cmd.Player.Send(
+		event(SetPointsAwardedEvt, state.pointsAwarded),
+		event(SetTeamPointsAwardedEvt, state.teamPointsAwarded),
 		event(SetCurrentStateEvt, PostDrawing),
 		event(SetTimerEvt, state.endsAt.UTC()),
 	)
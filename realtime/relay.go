@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Relay mode has a few players share each word. The first one picks the
+// word, then they take turns on the same canvas with an equal slice of
+// the drawing time each, and split the drawer's points between them.
+
+const (
+	ScheduledRelayTurn ScheduledEventType = "relay_turn"
+
+	// Players a relay game needs to start, two to draw and one to guess
+	MIN_RELAY_PLAYERS = 3
+)
+
+var (
+	ErrNotYourTurn           = &CommandError{ErrCodeNotYourTurn, "it's not your turn to draw"}
+	ErrNotEnoughRelayPlayers = &CommandError{ErrCodeNotEnoughRelayPlayers, fmt.Sprintf("you need at least %d players to start a relay game", MIN_RELAY_PLAYERS)}
+)
+
+// RelayTurn tells everyone who is drawing right now in a relay game
+type RelayTurn struct {
+	PlayerID uuid.UUID `json:"playerId"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+func (r *room) isRelayGame() bool {
+	return r.Settings.GameMode == GameModeRelay
+}
+
+// Returns how many players draw each word. There's always
+// at least one player left to guess.
+func (r *room) drawersPerWord() int {
+	if !r.isRelayGame() {
+		return 1
+	}
+	return max(1, min(r.config().Game.RelayDrawers, r.activePlayerCount()-1))
+}
+
+// Splits the drawer's points for a guess between the players who drew it,
+// rounding up so everyone who drew gets something
+func splitDrawerPoints(points, drawers int) int {
+	if drawers <= 1 {
+		return points
+	}
+	return (points + drawers - 1) / drawers
+}
+
+// Starts the turn of the given drawer and schedules the next one.
+// Points still buffered from the last drawer are sent first so
+// they end up on the right stroke.
+func (state *DrawingState) startTurn(room *room, drawer *player) {
+	state.flush(room)
+
+	state.turnDrawer = drawer
+	state.turnEndsAt = time.Now().Add(state.turnDuration)
+	room.scheduler.addEvent(ScheduledRelayTurn, state.turnEndsAt, func() {
+		state.startTurn(room, state.nextDrawer(room))
+	})
+
+	room.broadcast(GameRoleAny,
+		event(SetRelayTurnEvt, state.relayTurn()),
+	)
+}
+
+// Returns the drawer after the one whose turn it is, turns go around
+// in the order the drawers were picked
+func (state *DrawingState) nextDrawer(room *room) *player {
+	drawers := room.currentDrawers
+	i := slices.Index(drawers, state.turnDrawer)
+	return drawers[(i+1)%len(drawers)]
+}
+
+// Returns whose turn it is, or nil outside of relay games
+func (state *DrawingState) relayTurn() *RelayTurn {
+	if state.turnDrawer == nil {
+		return nil
+	}
+	return &RelayTurn{PlayerID: state.turnDrawer.ID, EndsAt: state.turnEndsAt.UTC()}
+}
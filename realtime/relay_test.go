@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSplitDrawerPoints(t *testing.T) {
+	tests := []struct {
+		points, drawers, want int
+	}{
+		{points: 90, drawers: 1, want: 90},
+		{points: 90, drawers: 2, want: 45},
+		{points: 91, drawers: 2, want: 46},
+		{points: 1, drawers: 3, want: 1},
+		{points: 0, drawers: 3, want: 0},
+	}
+	for _, tt := range tests {
+		if got := splitDrawerPoints(tt.points, tt.drawers); got != tt.want {
+			t.Errorf("splitDrawerPoints(%d, %d) = %d, want %d", tt.points, tt.drawers, got, tt.want)
+		}
+	}
+}
+
+func TestRoom_GetNextDrawingPlayers(t *testing.T) {
+	room, players := newTestRoom(GameModeRelay, 5)
+
+	// Players who left since the queue was filled are skipped
+	delete(room.Players, players[1].ID)
+
+	drawers := room.getNextDrawingPlayers()
+	if len(drawers) != 2 || drawers[0] != players[0] || drawers[1] != players[2] {
+		t.Fatalf("expected the first two players still in the room, got %v", drawers)
+	}
+
+	// Someone is always left to guess
+	room.Settings.GameMode = GameModeClassic
+	if drawers := room.getNextDrawingPlayers(); len(drawers) != 1 {
+		t.Errorf("expected one drawer outside of relay games, got %d", len(drawers))
+	}
+	room.Settings.GameMode = GameModeRelay
+	delete(room.Players, players[4].ID)
+	if n := room.drawersPerWord(); n != 2 {
+		t.Errorf("expected 2 drawers with 3 players, got %d", n)
+	}
+	delete(room.Players, players[3].ID)
+	if n := room.drawersPerWord(); n != 1 {
+		t.Errorf("expected 1 drawer with 2 players, got %d", n)
+	}
+}
+
+func TestCanStartGame_Relay(t *testing.T) {
+	room, _ := newTestRoom(GameModeRelay, 2)
+	if err := canStartGame(room); !errors.Is(err, ErrNotEnoughRelayPlayers) {
+		t.Fatalf("expected %v, got %v", ErrNotEnoughRelayPlayers, err)
+	}
+
+	room, _ = newTestRoom(GameModeRelay, 3)
+	if err := canStartGame(room); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDrawingState_RelayTurns(t *testing.T) {
+	room, players := newTestRoom(GameModeRelay, 4)
+	lead, collaborator, guesser := players[0], players[1], players[2]
+	room.currentDrawers = []*player{lead, collaborator}
+
+	state := NewDrawingState(Word{Value: "apple", Difficulty: WordDifficultyHard}).(*DrawingState)
+	room.currentState = state
+	state.Enter(room)
+
+	if lead.GameRole != GameRoleDrawing || collaborator.GameRole != GameRoleDrawing {
+		t.Fatal("expected both drawers to get the drawing role")
+	}
+	if d := state.turnDuration; d > 30*time.Second || d < 29*time.Second {
+		t.Errorf("expected each drawer to get half the drawing time, got %v", state.turnDuration)
+	}
+
+	stroke := func(p *player) error {
+		return state.HandleCommand(room, &Command{
+			Type:    AddStrokeCmd,
+			Player:  p,
+			Payload: map[string]interface{}{"points": [][]int{{1, 1}}, "color": "#000000", "width": 5, "type": StrokeTypeBrush},
+		})
+	}
+
+	// The lead draws first, the collaborator waits for their turn
+	if err := stroke(lead); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stroke(collaborator); !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("expected %v, got %v", ErrNotYourTurn, err)
+	}
+	if err := stroke(guesser); !errors.Is(err, ErrOnlyDrawerCanAddStrokes) {
+		t.Fatalf("expected %v, got %v", ErrOnlyDrawerCanAddStrokes, err)
+	}
+
+	// Once the turn passes the collaborator draws on the same canvas
+	state.startTurn(room, state.nextDrawer(room))
+	if err := stroke(collaborator); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stroke(lead); !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("expected %v, got %v", ErrNotYourTurn, err)
+	}
+	if len(state.strokes) != 2 {
+		t.Errorf("expected both strokes on the shared canvas, got %d", len(state.strokes))
+	}
+
+	// A correct guess splits the drawer's points between the collaborators
+	if err := state.HandleCommand(room, &Command{Type: ChatMessageCmd, Player: guesser, Payload: "apple"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, drawerPoints := GuessPoints(2, 0, WordDifficultyHard)
+	want := splitDrawerPoints(drawerPoints, 2)
+	if lead.Score != want || collaborator.Score != want {
+		t.Errorf("expected each drawer to get %d, got %d and %d", want, lead.Score, collaborator.Score)
+	}
+
+	// The collaborator leaving on their turn hands it back to the lead
+	state.HandleCommand(room, &Command{Type: PlayerLeftCmd, Player: collaborator})
+	if state.turnDrawer != lead {
+		t.Error("expected the turn to pass when the drawer leaves")
+	}
+}
@@ -19,9 +19,6 @@ const (
 	// How often to check for idle players
 	IDLE_TICK = 1 * time.Minute
 
-	// How long a player can be idle before the room disconnects them
-	PLAYER_TIMEOUT = 15 * time.Minute
-
 	// Maximum length of a player's name
 	MAX_NAME_LENGTH = 14
 	MIN_NAME_LENGTH = 1
@@ -30,14 +27,6 @@ const (
 	SCHEDULER_TICK_INTERVAL = 100 * time.Millisecond
 )
 
-var (
-	// Duration allowed for players to pick a word
-	PickingPhaseDuration = time.Second * 15
-
-	// Duration after drawing for score updates and displaying the word
-	PostDrawingPhaseDuration = time.Second * 6
-)
-
 type ChatMessageType string
 
 const (
@@ -45,6 +34,7 @@ const (
 	ChatMessageTypeCorrect    ChatMessageType = "correct"
 	ChatMessageTypeCloseGuess ChatMessageType = "close_guess"
 	ChatMessageTypeSystem     ChatMessageType = "system"
+	ChatMessageTypeSpectator  ChatMessageType = "spectator"
 )
 
 type ChatMessage struct {
@@ -54,33 +44,33 @@ type ChatMessage struct {
 	Content  string          `json:"content"`
 }
 
-// Room settings validation constants
-const (
-	MIN_PLAYERS      = 2
-	MAX_PLAYERS      = 10
-	MIN_DRAWING_TIME = 15  // seconds
-	MAX_DRAWING_TIME = 240 // seconds
-	MIN_ROUNDS       = 1
-	MAX_ROUNDS       = 10
-)
+// Fewest players a game can be played with, the other
+// room settings bounds are in RoomsConfig
+const MIN_PLAYERS = 2
 
 // We send these to clients to display alerts
 var (
 	ErrRoomNotFound      = errors.New("ErrRoomNotFound")
 	ErrRoomFull          = errors.New("ErrRoomFull")
+	ErrSpectatorsFull    = errors.New("ErrSpectatorsFull")
 	ErrRoomClosed        = errors.New("ErrRoomClosed")
 	ErrConnectionTimeout = errors.New("ErrConnectionTimeout")
 	ErrRoomIdle          = errors.New("ErrRoomIdle")
+	ErrRoomUnresponsive  = errors.New("ErrRoomUnresponsive")
 	ErrPlayerIdle        = errors.New("ErrPlayerIdle")
 	ErrRoomEmpty         = errors.New("ErrRoomEmpty")
 	ErrNameTooLong       = errors.New("ErrNameTooLong")
+	ErrTooManyRooms      = errors.New("ErrTooManyRooms")
 )
 
 type GameMode string
 
 const (
-	GameModeClassic GameMode = "classic"
-	GameModeNoHints GameMode = "noHints"
+	GameModeClassic   GameMode = "classic"
+	GameModeNoHints   GameMode = "noHints"
+	GameModeTeams     GameMode = "teams"
+	GameModeTelephone GameMode = "telephone"
+	GameModeRelay     GameMode = "relay"
 )
 
 type RoomSettings struct {
@@ -89,8 +79,19 @@ type RoomSettings struct {
 	TotalRounds        int            `json:"totalRounds"`
 	WordDifficulty     WordDifficulty `json:"wordDifficulty"`
 	GameMode           GameMode       `json:"gameMode"`
+	ScoringMode        ScoringMode    `json:"scoringMode"`
 	WordBank           WordBank       `json:"wordBank"`
 	CustomWords        []Word         `json:"customWords"`
+
+	// Public rooms are listed in the room browser and used for quick play
+	Public bool `json:"public"`
+
+	// Only sent by the host to change the password, nil keeps the current one
+	// and an empty string removes it. It's never sent back to clients.
+	Password *string `json:"password,omitempty"`
+
+	// Whether players need a password to join, set by the server
+	HasPassword bool `json:"hasPassword"`
 }
 
 // Room manages the game state, player connections, and coordinates all room-related activities.
@@ -103,8 +104,21 @@ type RoomSettings struct {
 type Room interface {
 	Close(cause error)
 	Connect(conn *websocket.Conn, player *player) error
+	Resume(conn *websocket.Conn, token, ip string) error
 	Run(rm RoomManager)
 	Code() string
+	Status(timeout time.Duration) (RoomStatus, error)
+	CheckPassword(ip, password string) error
+}
+
+// RoomStatus is a snapshot of a room reported by the room's goroutine.
+type RoomStatus struct {
+	LastActivityAt time.Time
+	Players        int // Spectators aren't counted, they don't take a seat
+	Spectators     int
+	Phase          int // The game state constant of the current state, ex. Waiting
+	CurrentRound   int
+	Settings       RoomSettings
 }
 
 type room struct {
@@ -114,52 +128,123 @@ type room struct {
 	CurrentRound int                   `json:"currentRound"`
 	ChatMessages []ChatMessage         `json:"chatMessages"`
 
+	// Chat between spectators, players never see it
+	SpectatorChat []ChatMessage `json:"-"`
+
 	// game state
-	currentState  RoomState
-	drawingQueue  []uuid.UUID
-	currentDrawer *player
+	currentState RoomState
+	drawingQueue []uuid.UUID
+
+	// Players drawing the current word, the first one picks it.
+	// There's only one unless it's a relay game.
+	currentDrawers []*player
+
+	// Results of the last game, shown in the lobby until the next game starts
+	finalStandings []FinalStanding
+	teamStandings  []TeamStanding
+
+	// Vote to remove a player that's currently open, if any
+	voteKick *voteKick
+
+	// When players last started a vote kick and when players were last
+	// voted on, used to keep players from spamming votes
+	voteKickStartedAt  map[uuid.UUID]time.Time
+	voteKickTargetedAt map[uuid.UUID]time.Time
+
+	// Players who can't join or resume again, see roomBan
+	bannedPlayers map[uuid.UUID]roomBan
+	bannedIPs     map[string]roomBan
+
+	// Password players need to join, see roomPassword
+	password roomPassword
+
+	// Limits and timings the room runs with
+	cfg *Config
 
 	// channels
 	connect    chan *connectionAttempt
-	disconnect chan *player
+	reconnect  chan *resumeAttempt
+	disconnect chan *disconnection
 	command    chan *Command
+	status     chan chan RoomStatus
 
 	scheduler *GameScheduler
 
+	// Last time a player did something in the room
+	lastActivityAt time.Time
+
+	// When the room entered its current state, for the phase duration metric.
+	// It's zero until the state is entered.
+	stateEnteredAt time.Time
+
 	// context
+	ctx    context.Context
 	cancel context.CancelCauseFunc
 }
 
-func NewRoom(id string) Room {
+func NewRoom(id string, cfg *Config) Room {
+	return newRoom(id, cfg)
+}
+
+func newRoom(id string, cfg *Config) *room {
+	// We use a cancelable context for graceful room shutdowns:
+	//
+	// 1. The room has a main context.
+	// 2. When a new client connects, we create a child context from the room's context.
+	// 3. If the room needs to shut down, canceling the main context will also cancel all client contexts.
+	// 4. This ensures all goroutines (room and clients) are properly terminated, preventing leaks.
+	// 5. We also use this context to propagate cancellation causes to the clients to tell them why the room was closed.
+	//
+	// It's created here rather than in Run so the room can be closed before its goroutine starts.
+	ctx, cancel := context.WithCancelCause(context.Background())
+
 	return &room{
 		ID:            id,
+		cfg:           cfg,
 		Players:       make(map[uuid.UUID]*player),
 		connect:       make(chan *connectionAttempt),
-		disconnect:    make(chan *player),
+		reconnect:     make(chan *resumeAttempt),
+		disconnect:    make(chan *disconnection),
 		command:       make(chan *Command, 5),
+		status:        make(chan chan RoomStatus),
 		drawingQueue:  make([]uuid.UUID, 0),
-		currentDrawer: nil,
 		ChatMessages:  make([]ChatMessage, 0),
+		SpectatorChat: make([]ChatMessage, 0),
 		scheduler:     NewGameScheduler(),
 
 		Settings: RoomSettings{
-			PlayerLimit:        6,
+			PlayerLimit:        min(6, cfg.Rooms.MaxPlayers),
 			DrawingTimeAllowed: 90,
 			TotalRounds:        3,
 			WordDifficulty:     WordDifficultyAll,
 			GameMode:           GameModeClassic,
+			ScoringMode:        ScoringModeClassic,
 			WordBank:           WordBankMixed,
 			CustomWords:        make([]Word, 0),
 		},
 
-		currentState: &WaitingState{},
+		currentState:   &WaitingState{},
+		stateEnteredAt: time.Now(),
+
+		lastActivityAt: time.Now(),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Returns the config the room was created with, or the defaults
+// for rooms that weren't given one
+func (r *room) config() *Config {
+	if r.cfg == nil {
+		return defaultConfig
 	}
+	return r.cfg
 }
 
 func (r *room) Transition() {
 	// States should manage their own events
 	r.currentState.Exit(r)
-	r.currentState.Enter(r)
+	r.enterState()
 }
 
 // Only use this if we need to exit the transiton cycle
@@ -167,8 +252,8 @@ func (r *room) Transition() {
 // the minimum required to start the game.
 func (r *room) TransitionTo(state RoomState) {
 	r.currentState.Exit(r)
-	r.currentState = state
-	r.currentState.Enter(r)
+	r.setState(state)
+	r.enterState()
 }
 
 // Returns the room's code
@@ -176,10 +261,23 @@ func (r *room) Code() string {
 	return r.ID
 }
 
-// Sets the room's current state
+// Sets the room's current state, see enterState to enter it
 func (r *room) setState(state RoomState) {
 	slog.Debug("setting state to", "state", state)
+
+	// States that are replaced before they're entered, like the one Exit
+	// sets before TransitionTo picks another, never ran so aren't timed
+	if !r.stateEnteredAt.IsZero() {
+		phaseDuration.observe(phaseName(phaseOf(r.currentState)), time.Since(r.stateEnteredAt).Seconds())
+	}
 	r.currentState = state
+	r.stateEnteredAt = time.Time{}
+}
+
+// Enters the room's current state and starts timing it
+func (r *room) enterState() {
+	r.stateEnteredAt = time.Now()
+	r.currentState.Enter(r)
 }
 
 // A connection attempt from a player to the room.
@@ -215,16 +313,62 @@ func (r *room) Connect(conn *websocket.Conn, p *player) error {
 	return err
 }
 
+// An attempt to reattach a new connection to a player that is already in the room.
+type resumeAttempt struct {
+	conn     *websocket.Conn
+	playerID uuid.UUID
+	remoteIP string
+	result   chan error
+}
+
+// Attempts to resume a player's session using the resume token they were
+// given when they first joined the room.
+func (r *room) Resume(conn *websocket.Conn, token, ip string) error {
+	roomID, playerID, err := verifyResumeToken(r.config().Sessions.resumeKey, token)
+	if err != nil || roomID != r.ID {
+		return ErrInvalidResumeToken
+	}
+
+	attempt := &resumeAttempt{
+		conn:     conn,
+		playerID: playerID,
+		remoteIP: ip,
+		result:   make(chan error),
+	}
+
+	// Send resume request to the room's goroutine
+	select {
+	case r.reconnect <- attempt:
+	case <-time.After(5 * time.Second):
+		return ErrConnectionTimeout
+	}
+
+	// Wait for the result
+	return <-attempt.result
+}
+
+// A client's connection to the room ending, along with the reason why.
+type disconnection struct {
+	client *client
+	cause  error
+}
+
 // Adds the player to the room state, initializes their client,
 // and informs the other players they joined.
 func (r *room) register(ctx context.Context, player *player) error {
-	// Check if the room is full
-	if len(r.Players) >= r.Settings.PlayerLimit {
-		return ErrRoomFull
+	if err := r.checkBanned(player.ID, player.remoteIP); err != nil {
+		return err
+	}
+	if err := r.checkCapacity(player); err != nil {
+		return err
 	}
 
 	// Start their client and add the player to the room
 	player.client.run(ctx)
+	player.joinedAt = time.Now()
+	if r.isTeamGame() && !player.isSpectator() {
+		player.Team = r.smallestTeam()
+	}
 	r.Players[player.ID] = player
 
 	// Tell the other players that a new player joined
@@ -232,14 +376,22 @@ func (r *room) register(ctx context.Context, player *player) error {
 		event(PlayerJoinedEvt, player),
 	)
 
-	// Send the player the current room state and tell them what their ID is
+	// Send the player the current room state and tell them who they are
 	player.Send(
-		event(SetPlayerIdEvt, player.ID),
+		event(SetPlayerIdEvt, NewPlayerSession(r.config().Sessions.resumeKey, r.ID, player.ID)),
 		event(RoomInitEvt, r),
 	)
 
-	// Add the player to the drawing queue
-	r.enqueueDrawingPlayer(player)
+	if r.voteKick != nil {
+		player.Send(event(SetVoteKickEvt, r.voteKickStatus()))
+	}
+
+	// Add the player to the drawing queue, spectators never draw
+	if player.isSpectator() {
+		player.Send(event(SetSpectatorChatEvt, r.SpectatorChat))
+	} else {
+		r.enqueueDrawingPlayer(player)
+	}
 
 	// Send the player the current drawing state
 	r.dispatch(&Command{
@@ -252,6 +404,112 @@ func (r *room) register(ctx context.Context, player *player) error {
 	return nil
 }
 
+// Checks if there is room for the player to join.
+// Spectators don't take a seat, they have their own limit.
+func (r *room) checkCapacity(player *player) error {
+	if player.isSpectator() {
+		if len(r.Players)-r.playerCount() >= r.config().Rooms.MaxSpectators {
+			return ErrSpectatorsFull
+		}
+		return nil
+	}
+
+	if r.playerCount() >= r.Settings.PlayerLimit {
+		return ErrRoomFull
+	}
+	return nil
+}
+
+// Attaches a new connection to a player that is still in the room
+// and replays the current room and game state to them.
+func (r *room) resume(ctx context.Context, attempt *resumeAttempt) error {
+	if err := r.checkBanned(attempt.playerID, attempt.remoteIP); err != nil {
+		return err
+	}
+	player, ok := r.Players[attempt.playerID]
+	if !ok {
+		return ErrSessionExpired
+	}
+
+	// The old connection may not have noticed it's dead yet,
+	// so we close it before the new one takes over.
+	if player.client != nil {
+		player.client.close(ErrSessionReplaced)
+	}
+
+	// Start the new client
+	player.client = NewClient(attempt.conn, r, player)
+	player.client.run(ctx)
+	player.remoteIP = attempt.remoteIP
+	player.disconnectedAt = time.Time{}
+	player.lastInteractionAt = time.Now()
+
+	// Tell the other players they are back
+	r.broadcast(GameRoleAny,
+		event(PlayerReconnectedEvt, player.ID),
+	)
+
+	// Send the player the current room state with a fresh session
+	player.Send(
+		event(SetPlayerIdEvt, NewPlayerSession(r.config().Sessions.resumeKey, r.ID, player.ID)),
+		event(RoomInitEvt, r),
+	)
+	if player.isSpectator() {
+		player.Send(event(SetSpectatorChatEvt, r.SpectatorChat))
+	}
+	if r.voteKick != nil {
+		player.Send(event(SetVoteKickEvt, r.voteKickStatus()))
+	}
+
+	// Send the player the current game state
+	r.dispatch(&Command{
+		Type:    PlayerJoinedCmd,
+		Player:  player,
+		Payload: nil,
+	})
+
+	slog.Debug("player resumed session", "playerId", player.ID)
+	return nil
+}
+
+// Handles a client's connection to the room ending.
+//
+// Players who lost their connection unexpectedly are held in the room for the
+// resume grace period so they can pick up where they left off. Everyone else
+// is removed from the room right away.
+func (r *room) handleDisconnect(d *disconnection) {
+	player := d.client.player
+
+	// The player already resumed with a newer client, this one is stale
+	if player.client != d.client {
+		slog.Debug("ignoring disconnect from replaced client", "playerId", player.ID)
+		return
+	}
+
+	if r.config().Sessions.ResumeGracePeriod.Duration <= 0 || !isResumableCause(d.cause) {
+		r.unregister(player)
+		return
+	}
+
+	player.client = nil
+	player.disconnectedAt = time.Now()
+
+	r.broadcast(GameRoleAny,
+		event(PlayerDisconnectedEvt, player.ID),
+	)
+	slog.Debug("player disconnected, holding slot for resume", "playerId", player.ID, "cause", d.cause)
+}
+
+// Removes players that have been disconnected for longer than the resume grace period.
+func (r *room) removeExpiredSessions(now time.Time) {
+	for _, player := range r.Players {
+		if !player.isConnected() && now.Sub(player.disconnectedAt) > r.config().Sessions.ResumeGracePeriod.Duration {
+			slog.Debug("resume grace period expired", "playerId", player.ID)
+			r.unregister(player)
+		}
+	}
+}
+
 // Removes the player from the room state, informs the other players,
 // and handles necessary game state changes if they disconnect mid-game.
 func (room *room) unregister(player *player) {
@@ -260,6 +518,8 @@ func (room *room) unregister(player *player) {
 	// 2. Useful for scenarios like kicking players for offensive language
 	// Note: This is a design choice that may have future benefits.
 
+	room.handleVoteKickPlayerLeft(player)
+
 	// Filter out the player's messages
 	newChatMessages := make([]ChatMessage, 0)
 	for _, g := range room.ChatMessages {
@@ -270,6 +530,16 @@ func (room *room) unregister(player *player) {
 	room.ChatMessages = newChatMessages
 
 	room.broadcast(GameRoleAny, event(SetChatEvt, room.ChatMessages))
+
+	if player.isSpectator() {
+		spectatorChat := make([]ChatMessage, 0, len(room.SpectatorChat))
+		for _, msg := range room.SpectatorChat {
+			if msg.PlayerID != player.ID {
+				spectatorChat = append(spectatorChat, msg)
+			}
+		}
+		room.SpectatorChat = spectatorChat
+	}
 	room.SendSystemMessage(fmt.Sprintf("%s left the room", player.Username))
 	room.removePlayerFromDrawingQueue(player.ID)
 	room.currentState.HandleCommand(room, &Command{
@@ -288,18 +558,8 @@ func (room *room) unregister(player *player) {
 
 	// If the player is the host, we need to migrate the host role to a new player
 	if player.RoomRole == RoomRoleHost {
-		// Assign the host role to the first player we find
-		for _, p := range room.Players {
-			if p.ID == player.ID {
-				continue
-			}
-			p.RoomRole = RoomRoleHost
-			room.broadcast(
-				GameRoleAny,
-				event(SetPlayersEvt, room.Players),
-			)
-			slog.Debug("host changed to", "playerId", p.ID)
-			break
+		if next := room.nextHost(player.ID); next != nil {
+			room.setHost(next)
 		}
 	}
 
@@ -307,16 +567,17 @@ func (room *room) unregister(player *player) {
 	delete(room.Players, player.ID)
 
 	// If there are no players left in the room, we need to cancel the game
-	// and reset the room state.
-	if len(room.Players) == 0 {
-		room.cancel(errors.New("no players left in room"))
+	// and reset the room state. Spectators can't do anything on their own,
+	// so the room closes on them too.
+	if room.playerCount() == 0 {
+		room.cancel(ErrRoomEmpty)
 		slog.Debug("no players left in room, closing room", "id", room.ID)
 		return
 	}
 
-	// If there are less than 2 players left in the room, we need to cancel the game
+	// If there are less than 2 players left in the game, we need to cancel the game
 	// and reset the room state since there's no way to continue the game.
-	if len(room.Players) < 2 {
+	if room.activePlayerCount() < MIN_PLAYERS {
 		room.scheduler.clearEvents()
 		room.TransitionTo(NewWaitingState())
 
@@ -325,9 +586,14 @@ func (room *room) unregister(player *player) {
 			event(SetCurrentStateEvt, Waiting),
 			event(Error, "Not enough players to continue game"),
 		)
-	} else if player.GameRole == GameRoleDrawing {
-		slog.Debug("player left during drawing phase, transitioning to post-drawing phase")
-		room.Transition()
+	} else if room.isDrawer(player.ID) {
+		// The drawing goes on as long as someone is left to draw it.
+		// Everyone draws in telephone games, those steps move on by themselves.
+		room.removeDrawer(player)
+		if len(room.currentDrawers) == 0 {
+			slog.Debug("player left during drawing phase, transitioning to post-drawing phase")
+			room.Transition()
+		}
 	}
 
 	slog.Debug("player unregistered", "playerId", player.ID)
@@ -338,17 +604,24 @@ func (room *room) unregister(player *player) {
 // For example, we use this to send the hinted word only to guessing players
 // since the drawing player already knows the real world word.
 func (r *room) broadcast(role GameRole, events ...*Event) {
+	// Every recipient shares the same frame, so the events
+	// are only encoded once no matter how many players there are.
+	f := newFrame(events...)
+
 	for _, player := range r.Players {
 		if role == GameRoleAny || player.GameRole == role {
-			// Veriatic function arugments allow us to send arbitrary number of actions
-			// to the player as a single call.
-			//
-			// Read more: https://gobyexample.com/variadic-functions
-			player.Send(events...)
+			player.sendFrame(f)
 		}
 	}
 }
 
+// Counts a command sent by a client and dispatches it. The room also
+// dispatches commands itself, like when a player joins, those aren't counted.
+func (r *room) handleClientCommand(cmd *Command) {
+	commandsHandled.inc(commandMetricLabel(cmd.Type))
+	r.dispatch(cmd)
+}
+
 // Dispatches an action to the room, validating it and executing it.
 //
 // This is the single entry point for incoming actions being sent from clients.
@@ -356,15 +629,60 @@ func (r *room) broadcast(role GameRole, events ...*Event) {
 func (r *room) dispatch(cmd *Command) {
 	player := cmd.Player
 	player.lastInteractionAt = time.Now()
+	r.lastActivityAt = player.lastInteractionAt
 
+	var err error
 	switch cmd.Type {
 	case UpdatePlayerProfileCmd:
-		r.handlePlayerProfileChange(cmd)
+		err = r.handlePlayerProfileChange(cmd)
+	case KickPlayerCmd:
+		err = r.handleKickPlayer(cmd)
+	case BanPlayerCmd:
+		err = r.handleBanPlayer(cmd)
+	case MutePlayerCmd:
+		err = r.handleMutePlayer(cmd)
+	case TransferHostCmd:
+		err = r.handleTransferHost(cmd)
+	case StartVoteKickCmd:
+		err = r.handleStartVoteKick(cmd)
+	case VoteKickCmd:
+		err = r.handleVoteKick(cmd)
 	case ChatMessageCmd:
-		err := r.currentState.HandleCommand(r, cmd)
-		if err != nil {
+		// States assume chat payloads are strings
+		chatValue, ok := cmd.Payload.(string)
+		if !ok {
+			err = ErrInvalidChatMessage
+			break
+		}
+
+		// Muted players' messages never reach anyone else. They can still
+		// guess while someone is drawing, the drawing state drops the rest.
+		if _, drawing := r.currentState.(*DrawingState); player.Muted && (!drawing || player.isSpectator()) {
+			err = ErrMuted
+			break
+		}
+
+		// Spectators have their own chat, so they can't give away answers
+		if player.isSpectator() {
+			r.handleSpectatorChatMessage(ChatMessage{
+				ID:       uuid.New(),
+				PlayerID: player.ID,
+				Content:  sanitizeChatMessage(chatValue),
+				Type:     ChatMessageTypeSpectator,
+			})
+			break
+		}
+
+		// States that don't handle chat messages let the room post them.
+		// Any other error means the state kept the message back on purpose.
+		if err = r.currentState.HandleCommand(r, cmd); errorCodeOf(err) == ErrCodeInvalidCommand {
+			err = nil
+			if player.Muted {
+				err = ErrMuted
+				break
+			}
 			slog.Debug("handling chat message at room level from player", "playerId", player.ID)
-			msg := sanitizeChatMessage(cmd.Payload.(string))
+			msg := sanitizeChatMessage(chatValue)
 			r.handleChatMessage(ChatMessage{
 				ID:       uuid.New(),
 				PlayerID: player.ID,
@@ -373,9 +691,33 @@ func (r *room) dispatch(cmd *Command) {
 			})
 		}
 	default:
-		r.currentState.HandleCommand(r, cmd)
+		err = r.currentState.HandleCommand(r, cmd)
 	}
 
+	r.respond(cmd, err)
+}
+
+// Lets the player who sent a command know how it went.
+//
+// Failed commands are always reported so the player can see why their action
+// didn't go through. Successful commands are only acknowledged if the client
+// attached a request ID to them.
+func (r *room) respond(cmd *Command, err error) {
+	if err != nil {
+		slog.Debug("command failed",
+			"type", cmd.Type,
+			"playerId", cmd.Player.ID,
+			"code", errorCodeOf(err),
+			"error", err,
+		)
+		commandErrors.inc(string(errorCodeOf(err)))
+		cmd.Player.Send(event(CommandErrorEvt, NewCommandResult(cmd, err)))
+		return
+	}
+
+	if cmd.RequestID != "" {
+		cmd.Player.Send(event(CommandAckEvt, NewCommandResult(cmd, nil)))
+	}
 }
 
 type PlayerProfileChange struct {
@@ -420,21 +762,21 @@ func (r *room) handlePlayerProfileChange(cmd *Command) error {
 func (r *room) Run(rm RoomManager) {
 	slog.Info("Room created", "id", r.ID)
 
-	// We use a cancelable context for graceful room shutdowns:
-	//
-	// 1. The room has a main context.
-	// 2. When a new client connects, we create a child context from the room's context.
-	// 3. If the room needs to shut down, canceling the main context will also cancel all client contexts.
-	// 4. This ensures all goroutines (room and clients) are properly terminated, preventing leaks.
-	// 5. We also use this context to propagate cancellation causes to the clients to tell them why the room was closed.
-	ctx, cancel := context.WithCancelCause(context.Background())
-	r.cancel = cancel
-	defer cancel(nil)
+	ctx := r.ctx
+	defer r.cancel(nil)
 
 	// Used to check for idle players on a regular interval
 	idleTicker := time.NewTicker(IDLE_TICK)
 	defer idleTicker.Stop()
 
+	// Used to remove disconnected players that never came back
+	resumeTicker := time.NewTicker(RESUME_TICK)
+	defer resumeTicker.Stop()
+
+	// Used to send buffered stroke points to players
+	flushTicker := time.NewTicker(r.config().Game.StrokeFlushInterval.Duration)
+	defer flushTicker.Stop()
+
 	// Used to tick the scheduler
 	schedulerTicker := time.NewTicker(SCHEDULER_TICK_INTERVAL)
 	defer schedulerTicker.Stop()
@@ -442,7 +784,7 @@ func (r *room) Run(rm RoomManager) {
 	// This runs when this routine exits, we can do cleanup here
 	defer func() {
 		slog.Debug("Room routine exiting, unregistering room", "id", r.ID)
-		rm.Unregister(r.ID)
+		rm.Unregister(r)
 	}()
 
 	for {
@@ -453,8 +795,14 @@ func (r *room) Run(rm RoomManager) {
 			return
 		case <-idleTicker.C:
 			for _, player := range r.Players {
+				// Disconnected players are cleaned up by the resume ticker,
+				// and spectators are expected to just watch
+				if !player.isConnected() || player.isSpectator() {
+					continue
+				}
+
 				// Disconnect players that haven't interacted with the room in a while
-				if time.Since(player.lastInteractionAt) > PLAYER_TIMEOUT {
+				if time.Since(player.lastInteractionAt) > r.config().Rooms.PlayerTimeout.Duration {
 					player.client.close(ErrPlayerIdle)
 					slog.Debug("Player is idle, disconnecting",
 						"player", player.ID,
@@ -462,23 +810,79 @@ func (r *room) Run(rm RoomManager) {
 					)
 				}
 			}
+		case now := <-resumeTicker.C:
+			r.removeExpiredSessions(now)
+		case <-flushTicker.C:
+			if state, ok := r.currentState.(flusher); ok {
+				state.flush(r)
+			}
 		case <-schedulerTicker.C:
 			// Tick the scheduler
 			r.scheduler.tick(SCHEDULER_TICK_INTERVAL)
 		case req := <-r.connect:
 			// A new client has connected to the room
+			r.lastActivityAt = time.Now()
 			req.result <- r.register(ctx, req.player)
-		case player := <-r.disconnect:
+		case req := <-r.reconnect:
+			// A disconnected client is trying to resume their session
+			r.lastActivityAt = time.Now()
+			req.result <- r.resume(ctx, req)
+		case d := <-r.disconnect:
 			// A client has disconnected from the room
-			r.unregister(player)
+			r.handleDisconnect(d)
+		case req := <-r.status:
+			// The room manager is checking in on the room
+			req <- r.snapshot()
 		case cmd := <-r.command:
 			// Client routines send commands to the room via this channel
-			r.dispatch(cmd)
+			r.handleClientCommand(cmd)
 		}
 
 	}
 }
 
+// Builds a snapshot of the room, this has to run on the room's goroutine.
+//
+// The snapshot gets its own copy of anything the room could change
+// while it's being read from another goroutine.
+func (r *room) snapshot() RoomStatus {
+	settings := r.Settings
+	settings.CustomWords = slices.Clone(r.Settings.CustomWords)
+
+	return RoomStatus{
+		LastActivityAt: r.lastActivityAt,
+		Players:        r.playerCount(),
+		Spectators:     len(r.Players) - r.playerCount(),
+		Phase:          phaseOf(r.currentState),
+		CurrentRound:   r.CurrentRound,
+		Settings:       settings,
+	}
+}
+
+// Asks the room's goroutine for a snapshot of the room.
+//
+// The room manager uses this to find idle rooms and rooms whose goroutine
+// has stopped responding, which fail with ErrRoomUnresponsive.
+func (r *room) Status(timeout time.Duration) (RoomStatus, error) {
+	req := make(chan RoomStatus, 1)
+	deadline := time.After(timeout)
+
+	select {
+	case r.status <- req:
+	case <-r.ctx.Done():
+		return RoomStatus{}, ErrRoomClosed
+	case <-deadline:
+		return RoomStatus{}, ErrRoomUnresponsive
+	}
+
+	select {
+	case status := <-req:
+		return status, nil
+	case <-deadline:
+		return RoomStatus{}, ErrRoomUnresponsive
+	}
+}
+
 // Closes the room with a cause.
 // We use this to propagate close messages to clients
 // which derived their context from the room's context.
@@ -492,13 +896,40 @@ func (r *room) resetGameState() {
 		p.GameRole = GameRoleGuessing
 		p.Score = 0
 		p.Streak = 0
+		p.wordsDrawn = nil
+		p.correctGuesses = 0
 	}
 
-	r.currentDrawer = nil
+	r.finalStandings = nil
+	r.teamStandings = nil
+
+	r.currentDrawers = nil
 	r.drawingQueue = make([]uuid.UUID, 0)
 	r.CurrentRound = 0
 }
 
+// Returns the number of players in the room, not counting spectators
+func (r *room) playerCount() int {
+	count := 0
+	for _, p := range r.Players {
+		if !p.isSpectator() {
+			count++
+		}
+	}
+	return count
+}
+
+// Returns the number of players taking part in the current game
+func (r *room) activePlayerCount() int {
+	count := 0
+	for _, p := range r.Players {
+		if p.isActive() {
+			count++
+		}
+	}
+	return count
+}
+
 // Not the same as dequeueDrawingPlayer which returns the next player in the queue.
 // This specifically is used when a player leaves mid-game and we need to manually
 // remove them from the queue.
@@ -511,6 +942,97 @@ func (r *room) removePlayerFromDrawingQueue(playerID uuid.UUID) {
 	}
 }
 
+// HostChange is sent to players when the host role moves to another player
+type HostChange struct {
+	PreviousHostID uuid.UUID `json:"previousHostId"`
+	HostID         uuid.UUID `json:"hostId"`
+}
+
+// Makes the player the host of the room and tells everyone about it
+func (r *room) setHost(host *player) {
+	change := HostChange{HostID: host.ID}
+	for _, p := range r.Players {
+		if p.RoomRole == RoomRoleHost {
+			p.RoomRole = RoomRolePlayer
+			change.PreviousHostID = p.ID
+		}
+	}
+	host.RoomRole = RoomRoleHost
+
+	r.broadcast(GameRoleAny,
+		event(HostChangedEvt, change),
+	)
+	r.SendSystemMessage(fmt.Sprintf("%s is now the host", host.Username))
+	slog.Debug("host changed to", "playerId", host.ID)
+}
+
+// Picks who should take over as host, skipping the given player.
+//
+// Connected players are preferred over ones waiting to resume, then the
+// player who has been in the room the longest. Ties are broken by ID so the
+// same player is picked no matter how the players map is iterated.
+func (r *room) nextHost(skip uuid.UUID) *player {
+	var next *player
+	for _, p := range r.Players {
+		if p.ID == skip || p.isSpectator() {
+			continue
+		}
+		if next == nil || isBetterHost(p, next) {
+			next = p
+		}
+	}
+	return next
+}
+
+func isBetterHost(a, b *player) bool {
+	if a.isConnected() != b.isConnected() {
+		return a.isConnected()
+	}
+	if !a.joinedAt.Equal(b.joinedAt) {
+		return a.joinedAt.Before(b.joinedAt)
+	}
+	return a.ID.String() < b.ID.String()
+}
+
+// Checks if the player is one of the players drawing the current word
+func (r *room) isDrawer(id uuid.UUID) bool {
+	return slices.ContainsFunc(r.currentDrawers, func(p *player) bool {
+		return p.ID == id
+	})
+}
+
+// Returns the drawer who picked the current word, or nil if nobody is drawing
+func (r *room) leadDrawer() *player {
+	if len(r.currentDrawers) == 0 {
+		return nil
+	}
+	return r.currentDrawers[0]
+}
+
+// Takes a player who left off the current drawers
+func (r *room) removeDrawer(p *player) {
+	r.currentDrawers = slices.DeleteFunc(r.currentDrawers, func(d *player) bool {
+		return d.ID == p.ID
+	})
+}
+
+// Returns the number of players who can guess the current word
+func (r *room) guesserCount() int {
+	return r.activePlayerCount() - len(r.currentDrawers)
+}
+
+// Sends events to everyone in the room except one player, ex. strokes
+// to everyone but the player who drew them since they already have them
+func (r *room) broadcastExcept(except *player, events ...*Event) {
+	f := newFrame(events...)
+
+	for _, player := range r.Players {
+		if player != except {
+			player.sendFrame(f)
+		}
+	}
+}
+
 func (r *room) enqueueDrawingPlayer(player *player) {
 	r.drawingQueue = append(r.drawingQueue, player.ID)
 }
@@ -535,17 +1057,39 @@ func (room *room) getNextDrawingPlayer() *player {
 	return room.Players[next]
 }
 
+// Takes the players drawing the next word off the queue. Relay games take
+// one for each collaborator, players who left since the queue was filled are skipped.
+//
+// The last players of a round draw on their own if there aren't enough left.
+func (room *room) getNextDrawingPlayers() []*player {
+	drawers := make([]*player, 0, room.drawersPerWord())
+	for len(drawers) < room.drawersPerWord() && len(room.drawingQueue) > 0 {
+		if p := room.getNextDrawingPlayer(); p != nil {
+			drawers = append(drawers, p)
+		}
+	}
+	return drawers
+}
+
 // Initializes the drawing queue with all players.
 // The queue is sorted by score, so the first player in the queue
 // is the player with the highest score.
 func (room *room) fillDrawingQueue() {
+	// Teams take turns instead, see fillTeamDrawingQueue
+	if room.isTeamGame() {
+		room.fillTeamDrawingQueue()
+		return
+	}
+
 	// Clear existing queue
 	room.drawingQueue = make([]uuid.UUID, 0)
 
-	// Convert map to slice
+	// Convert map to slice, skipping players who are sitting out
 	players := make([]*player, 0, len(room.Players))
 	for _, p := range room.Players {
-		players = append(players, p)
+		if p.isActive() {
+			players = append(players, p)
+		}
 	}
 
 	// Sort players by score
@@ -559,6 +1103,23 @@ func (room *room) fillDrawingQueue() {
 	}
 }
 
+// Posts a message to the spectator chat, only spectators receive it
+func (room *room) handleSpectatorChatMessage(msg ChatMessage) {
+	room.SpectatorChat = append(room.SpectatorChat, msg)
+
+	// Trim old messages if we exceed the limit
+	if len(room.SpectatorChat) > MAX_CHAT_MESSAGES {
+		room.SpectatorChat = room.SpectatorChat[len(room.SpectatorChat)-MAX_CHAT_MESSAGES:]
+	}
+
+	f := newFrame(event(NewSpectatorChatEvt, msg))
+	for _, p := range room.Players {
+		if p.isSpectator() {
+			p.sendFrame(f)
+		}
+	}
+}
+
 func (room *room) handleChatMessage(msg ChatMessage) error {
 	room.ChatMessages = append(room.ChatMessages, msg)
 
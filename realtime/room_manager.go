@@ -3,21 +3,26 @@ package main
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
-	// Maximum number of rooms that can be created
-	MAX_ROOMS = 20
-
 	// How often to check for idle rooms
 	ROOM_TICK = 1 * time.Minute
 
-	// How long a room can be idle before it gets cleaned up
-	ROOM_TIMEOUT = 20 * time.Minute
+	// How long a room has to answer a status check before it's considered unresponsive
+	ROOM_STATUS_TIMEOUT = 5 * time.Second
+
+	// How long a room has to answer when listing public rooms.
+	// Rooms that are too slow are left out of the list rather than holding it up.
+	PUBLIC_ROOM_STATUS_TIMEOUT = 500 * time.Millisecond
 )
 
 // RoomManager is responsible for managing the lifecycle of rooms.
@@ -33,17 +38,48 @@ type RoomManager interface {
 	Run(ctx context.Context)
 	Room(id string) (Room, error)
 	Register() (Room, error)
-	Unregister(id string) error
+	RegisterPublic() (Room, error)
+	Unregister(room Room) error
+	PublicRooms() []PublicRoom
+}
+
+// PublicRoom is what the room browser shows about a public room
+type PublicRoom struct {
+	Code         string             `json:"code"`
+	Players      int                `json:"players"`
+	Spectators   int                `json:"spectators"`
+	Phase        int                `json:"phase"`
+	CurrentRound int                `json:"currentRound"`
+	Settings     PublicRoomSettings `json:"settings"`
+}
+
+// PublicRoomSettings are the room settings shown in the room browser.
+// Custom words are left out so they don't spoil the game.
+type PublicRoomSettings struct {
+	PlayerLimit        int            `json:"playerLimit"`
+	DrawingTimeAllowed int            `json:"drawingTimeAllowed"`
+	TotalRounds        int            `json:"totalRounds"`
+	WordDifficulty     WordDifficulty `json:"wordDifficulty"`
+	GameMode           GameMode       `json:"gameMode"`
+	WordBank           WordBank       `json:"wordBank"`
+	HasPassword        bool           `json:"hasPassword"`
 }
 
 type roomManager struct {
+	cfg   *Config
 	rooms map[string]Room
 	mu    sync.RWMutex
+
+	// Tells the manager what time it is, tests replace this
+	// so they don't have to wait for rooms to go idle.
+	now func() time.Time
 }
 
-func NewRoomManager() RoomManager {
+func NewRoomManager(cfg *Config) RoomManager {
 	return &roomManager{
+		cfg:   cfg,
 		rooms: make(map[string]Room),
+		now:   time.Now,
 	}
 }
 
@@ -63,16 +99,74 @@ func (rm *roomManager) Run(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			rm.reapIdleRooms()
 		}
 	}
 }
 
+// Closes rooms that have been idle for too long and unregisters
+// rooms whose goroutine has stopped responding.
+//
+// Rooms are checked concurrently so a few wedged rooms only hold up
+// the pass for one ROOM_STATUS_TIMEOUT rather than one each.
+func (rm *roomManager) reapIdleRooms() {
+	// Copy the registry so we don't hold the lock while waiting on rooms
+	rm.mu.RLock()
+	rooms := maps.Clone(rm.rooms)
+	rm.mu.RUnlock()
+
+	now := rm.now()
+	var wg sync.WaitGroup
+	for id, room := range rooms {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rm.reapRoom(id, room, now)
+		}()
+	}
+	wg.Wait()
+}
+
+// Closes the room if it's idle, or unregisters it if it doesn't respond
+func (rm *roomManager) reapRoom(id string, room Room, now time.Time) {
+	status, err := room.Status(ROOM_STATUS_TIMEOUT)
+	if errors.Is(err, ErrRoomClosed) {
+		// The room is already shutting down and will unregister itself
+		return
+	}
+	if err != nil {
+		// The room's goroutine is stuck, so it will never unregister itself.
+		// Closing it lets it exit if it ever recovers.
+		slog.Warn("room is not responding, unregistering", "id", id, "error", err)
+		room.Close(ErrRoomUnresponsive)
+		rm.Unregister(room)
+		return
+	}
+
+	if idleFor := now.Sub(status.LastActivityAt); idleFor > rm.cfg.Rooms.RoomTimeout.Duration {
+		// The room unregisters itself once its goroutine exits
+		slog.Info("room is idle, closing", "id", id, "idle_for", idleFor.Round(time.Second).String())
+		room.Close(ErrRoomIdle)
+	}
+}
+
 // Creates a new room and adds it to the registry.
 func (rm *roomManager) Register() (Room, error) {
+	return rm.register(false)
+}
+
+// Creates a new public room and adds it to the registry.
+// Quick play uses this when there's no public room to join.
+func (rm *roomManager) RegisterPublic() (Room, error) {
+	return rm.register(true)
+}
+
+func (rm *roomManager) register(public bool) (Room, error) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
-	if len(rm.rooms) >= MAX_ROOMS {
+	if len(rm.rooms) >= rm.cfg.Rooms.MaxRooms {
 		slog.Warn("maximum number of rooms reached, cannot create a new room")
 		return nil, fmt.Errorf("maximum number of rooms reached")
 	}
@@ -85,19 +179,28 @@ func (rm *roomManager) Register() (Room, error) {
 	}
 
 	// Create and store the room
-	room := NewRoom(id)
+	room := newRoom(id, rm.cfg)
+	room.Settings.Public = public
 	rm.rooms[id] = room
+	roomsCreated.inc()
+	roomsActive.inc()
 
 	return room, nil
 }
 
 // Removes a room from the registry.
 // This is called with the assumption that the room routine has already exited.
-func (rm *roomManager) Unregister(id string) error {
-	if _, err := rm.Room(id); err == nil {
-		rm.mu.Lock()
-		defer rm.mu.Unlock()
+//
+// Both the room and the manager may try to unregister a wedged room, and its
+// code may have been handed to a new room by then, so only the given room is removed.
+func (rm *roomManager) Unregister(room Room) error {
+	id := room.Code()
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if registered, ok := rm.rooms[id]; ok && registered == room {
 		delete(rm.rooms, id)
+		roomsActive.dec()
 		slog.Info("Room deleted", "id", id)
 		return nil
 	}
@@ -118,6 +221,98 @@ func (rm *roomManager) Room(id string) (Room, error) {
 	return nil, fmt.Errorf("room not found")
 }
 
+// Lists the public rooms that have a free seat, ordered by how good a fit
+// they are for a player looking for a game, see comparePublicRooms.
+//
+// Rooms are asked for their status concurrently, like in reapIdleRooms,
+// so slow rooms only hold up the list for one PUBLIC_ROOM_STATUS_TIMEOUT.
+func (rm *roomManager) PublicRooms() []PublicRoom {
+	// Copy the registry so we don't hold the lock while waiting on rooms
+	rm.mu.RLock()
+	rooms := maps.Clone(rm.rooms)
+	rm.mu.RUnlock()
+
+	// Each room writes to its own slot, rooms that aren't listed leave it empty
+	listed := make([]*PublicRoom, len(rooms))
+	var wg sync.WaitGroup
+	i := 0
+	for code, room := range rooms {
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			listed[slot] = publicRoom(code, room)
+		}(i)
+		i++
+	}
+	wg.Wait()
+
+	public := make([]PublicRoom, 0)
+	for _, room := range listed {
+		if room != nil {
+			public = append(public, *room)
+		}
+	}
+
+	slices.SortFunc(public, comparePublicRooms)
+	return public
+}
+
+// Returns what the room browser shows about the room, or nil
+// if the room is private, full or doesn't answer in time
+func publicRoom(code string, room Room) *PublicRoom {
+	status, err := room.Status(PUBLIC_ROOM_STATUS_TIMEOUT)
+	if err != nil {
+		return nil
+	}
+	if !status.Settings.Public || status.Players >= status.Settings.PlayerLimit {
+		return nil
+	}
+
+	return &PublicRoom{
+		Code:         code,
+		Players:      status.Players,
+		Spectators:   status.Spectators,
+		Phase:        status.Phase,
+		CurrentRound: status.CurrentRound,
+		Settings: PublicRoomSettings{
+			PlayerLimit:        status.Settings.PlayerLimit,
+			DrawingTimeAllowed: status.Settings.DrawingTimeAllowed,
+			TotalRounds:        status.Settings.TotalRounds,
+			WordDifficulty:     status.Settings.WordDifficulty,
+			GameMode:           status.Settings.GameMode,
+			WordBank:           status.Settings.WordBank,
+			HasPassword:        status.Settings.HasPassword,
+		},
+	}
+}
+
+// Orders public rooms for quick play.
+//
+// Rooms in the lobby come first since new players get to play the whole
+// game, then games in progress, then games that just ended. Within each,
+// fuller rooms come first so players end up together instead of spread
+// across many half empty rooms.
+func comparePublicRooms(a, b PublicRoom) int {
+	if pa, pb := phasePriority(a.Phase), phasePriority(b.Phase); pa != pb {
+		return pa - pb
+	}
+	if a.Players != b.Players {
+		return b.Players - a.Players
+	}
+	return strings.Compare(a.Code, b.Code)
+}
+
+func phasePriority(phase int) int {
+	switch phase {
+	case Waiting:
+		return 0
+	case GameOver:
+		return 2
+	default:
+		return 1
+	}
+}
+
 // Generates a unique room ID.
 // If there is a collision, it retries until it finds a unique ID.
 func (rm *roomManager) uniqueRoomID() (string, error) {
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// A room that reports whatever status the test gives it
+type fakeRoom struct {
+	id           string
+	status       RoomStatus
+	unresponsive bool
+	closedWith   error
+
+	// How long the room takes to answer a status check
+	delay time.Duration
+}
+
+func (f *fakeRoom) Close(cause error)                                   { f.closedWith = cause }
+func (f *fakeRoom) Connect(conn *websocket.Conn, p *player) error       { return nil }
+func (f *fakeRoom) Resume(conn *websocket.Conn, token, ip string) error { return nil }
+func (f *fakeRoom) Run(rm RoomManager)                                  {}
+func (f *fakeRoom) Code() string                                        { return f.id }
+func (f *fakeRoom) CheckPassword(ip, password string) error             { return nil }
+func (f *fakeRoom) Status(timeout time.Duration) (RoomStatus, error) {
+	time.Sleep(f.delay)
+	if f.unresponsive {
+		return RoomStatus{}, ErrRoomUnresponsive
+	}
+	return f.status, nil
+}
+
+func TestRoomManager_ReapIdleRooms(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	active := &fakeRoom{id: "AAAA", status: RoomStatus{LastActivityAt: now.Add(-time.Minute)}}
+	idle := &fakeRoom{id: "BBBB", status: RoomStatus{LastActivityAt: now.Add(-DefaultConfig().Rooms.RoomTimeout.Duration - time.Minute)}}
+	wedged := &fakeRoom{id: "CCCC", unresponsive: true}
+
+	rm := &roomManager{
+		cfg: DefaultConfig(),
+		rooms: map[string]Room{
+			active.id: active,
+			idle.id:   idle,
+			wedged.id: wedged,
+		},
+		now: func() time.Time { return now },
+	}
+
+	rm.reapIdleRooms()
+
+	if active.closedWith != nil {
+		t.Errorf("expected active room to stay open, closed with %v", active.closedWith)
+	}
+	if _, err := rm.Room(active.id); err != nil {
+		t.Errorf("expected active room to stay registered")
+	}
+
+	// Idle rooms are closed and unregister themselves when their goroutine exits
+	if idle.closedWith != ErrRoomIdle {
+		t.Errorf("expected idle room to be closed with ErrRoomIdle, got %v", idle.closedWith)
+	}
+
+	// Wedged rooms can't unregister themselves, so the manager does it
+	if wedged.closedWith != ErrRoomUnresponsive {
+		t.Errorf("expected wedged room to be closed with ErrRoomUnresponsive, got %v", wedged.closedWith)
+	}
+	if _, err := rm.Room(wedged.id); err == nil {
+		t.Errorf("expected wedged room to be unregistered")
+	}
+}
+
+func TestRoomManager_ReapIdleRooms_SlowRooms(t *testing.T) {
+	rm := &roomManager{
+		cfg:   DefaultConfig(),
+		rooms: make(map[string]Room),
+		now:   time.Now,
+	}
+	slow := make([]*fakeRoom, 5)
+	for i := range slow {
+		slow[i] = &fakeRoom{id: fmt.Sprintf("SLO%d", i), unresponsive: true, delay: 100 * time.Millisecond}
+		rm.rooms[slow[i].id] = slow[i]
+	}
+
+	// The rooms are waited on together, not one after another
+	start := time.Now()
+	rm.reapIdleRooms()
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("expected slow rooms to be checked concurrently, took %v", elapsed)
+	}
+
+	for _, room := range slow {
+		if room.closedWith != ErrRoomUnresponsive {
+			t.Errorf("expected room %s to be closed with ErrRoomUnresponsive, got %v", room.id, room.closedWith)
+		}
+	}
+	if len(rm.rooms) != 0 {
+		t.Errorf("expected all slow rooms to be unregistered, %d left", len(rm.rooms))
+	}
+}
+
+func TestRoomManager_Unregister(t *testing.T) {
+	wedged := &fakeRoom{id: "AAAA"}
+	rm := &roomManager{cfg: DefaultConfig(), rooms: map[string]Room{wedged.id: wedged}, now: time.Now}
+	active := roomsActive.value.Load()
+
+	// The manager unregisters the wedged room, then its goroutine recovers
+	// and tries again on the way out
+	if err := rm.Unregister(wedged); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rm.Unregister(wedged); err == nil {
+		t.Error("expected the second unregister to fail")
+	}
+	if got := roomsActive.value.Load(); got != active-1 {
+		t.Errorf("expected active rooms to go down once, went from %d to %d", active, got)
+	}
+
+	// A new room that got the same code isn't removed by the old one
+	reused := &fakeRoom{id: wedged.id}
+	rm.rooms[reused.id] = reused
+	if err := rm.Unregister(wedged); err == nil {
+		t.Error("expected unregistering the old room to fail")
+	}
+	if room, err := rm.Room(reused.id); err != nil || room != reused {
+		t.Error("expected the new room to stay registered")
+	}
+}
+
+func TestRoom_Status(t *testing.T) {
+	rm := NewRoomManager(DefaultConfig())
+	r, err := rm.Register()
+	if err != nil {
+		t.Fatalf("failed to register room: %v", err)
+	}
+
+	// Rooms that haven't started their goroutine don't answer
+	if _, err := r.Status(10 * time.Millisecond); err != ErrRoomUnresponsive {
+		t.Errorf("expected ErrRoomUnresponsive, got %v", err)
+	}
+
+	go r.Run(rm)
+	status, err := r.Status(time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.LastActivityAt.IsZero() {
+		t.Error("expected last activity to be set")
+	}
+	if status.Phase != Waiting {
+		t.Errorf("expected a new room to be in the lobby, got phase %d", status.Phase)
+	}
+
+	// Closed rooms report that they are shutting down
+	r.Close(ErrRoomIdle)
+	if _, err := r.Status(time.Second); err != ErrRoomClosed {
+		t.Errorf("expected ErrRoomClosed, got %v", err)
+	}
+}
+
+func TestRoomManager_PublicRooms(t *testing.T) {
+	public := func(id string, players, phase int) *fakeRoom {
+		return &fakeRoom{id: id, status: RoomStatus{
+			Players:  players,
+			Phase:    phase,
+			Settings: RoomSettings{PlayerLimit: 6, Public: true, CustomWords: []Word{{Value: "secret"}}},
+		}}
+	}
+
+	private := &fakeRoom{id: "PRIV", status: RoomStatus{Players: 1, Settings: RoomSettings{PlayerLimit: 6}}}
+	full := public("FULL", 6, Waiting)
+	wedged := public("WEDG", 1, Waiting)
+	wedged.unresponsive = true
+
+	rooms := []*fakeRoom{
+		private,
+		full,
+		wedged,
+		public("OVER", 5, GameOver),
+		public("GAME", 5, Drawing),
+		public("LOBA", 2, Waiting),
+		public("LOBB", 4, Waiting),
+		public("LOBC", 2, Waiting),
+	}
+	rm := &roomManager{cfg: DefaultConfig(), rooms: make(map[string]Room), now: time.Now}
+	for _, r := range rooms {
+		rm.rooms[r.id] = r
+	}
+
+	got := make([]string, 0)
+	for _, r := range rm.PublicRooms() {
+		got = append(got, r.Code)
+	}
+
+	// Lobbies first and fullest first, private, full and wedged rooms are left out
+	want := []string{"LOBB", "LOBA", "LOBC", "GAME", "OVER"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected public rooms %v, got %v", want, got)
+	}
+}
+
+func TestRoomManager_PublicRooms_SlowRooms(t *testing.T) {
+	rm := &roomManager{cfg: DefaultConfig(), rooms: make(map[string]Room), now: time.Now}
+	for i := 0; i < 5; i++ {
+		room := &fakeRoom{
+			id:     fmt.Sprintf("SLO%d", i),
+			status: RoomStatus{Players: 1, Settings: RoomSettings{PlayerLimit: 6, Public: true}},
+			delay:  100 * time.Millisecond,
+		}
+		rm.rooms[room.id] = room
+	}
+
+	// The rooms are waited on together, not one after another
+	start := time.Now()
+	rooms := rm.PublicRooms()
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("expected slow rooms to be checked concurrently, took %v", elapsed)
+	}
+	if len(rooms) != 5 {
+		t.Errorf("expected all slow rooms to be listed, got %d", len(rooms))
+	}
+}
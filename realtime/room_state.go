@@ -1,9 +1,5 @@
 package main
 
-import (
-	"errors"
-)
-
 // Game state constants representing different phases of the game
 const (
 	Waiting     = 100 // Initial state when waiting for players
@@ -11,11 +7,34 @@ const (
 	Drawing     = 201 // State when active player is drawing
 	PostDrawing = 202 // State after drawing is complete
 	GameOver    = 203 // State when the game has ended
+
+	Telephone       = 204 // State when everyone is writing or drawing in a telephone game
+	TelephoneReveal = 205 // State when the chains of a telephone game are shown
 )
 
+// Returns the game state constant for a room state, ex. Waiting for the WaitingState
+func phaseOf(state RoomState) int {
+	switch state.(type) {
+	case *PickingState:
+		return Picking
+	case *DrawingState:
+		return Drawing
+	case *PostDrawingState:
+		return PostDrawing
+	case *GameOverState:
+		return GameOver
+	case *TelephoneState:
+		return Telephone
+	case *TelephoneRevealState:
+		return TelephoneReveal
+	default:
+		return Waiting
+	}
+}
+
 // Error definitions for invalid player actions
-var ErrWrongRoomRole = errors.New("player does not have the correct room role to perform this action")
-var ErrWrongGameRole = errors.New("player does not have the correct game role to perform this action")
+var ErrWrongRoomRole = &CommandError{ErrCodeWrongRoomRole, "player does not have the correct room role to perform this action"}
+var ErrWrongGameRole = &CommandError{ErrCodeWrongGameRole, "player does not have the correct game role to perform this action"}
 
 // RoomState defines the interface for different game states
 // Each state implements its own behavior for entering, exiting,
@@ -31,3 +50,9 @@ type RoomState interface {
 	// Returns an error if the command cannot be handled
 	HandleCommand(room *room, cmd *Command) error
 }
+
+// flusher is implemented by states that buffer events for players
+// and send them on an interval, see DrawingState.flush
+type flusher interface {
+	flush(room *room)
+}
@@ -2,11 +2,48 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Sets up a lobby for the game mode with n players in the order they
+// joined, the first one is the host
+func newTestRoom(mode GameMode, n int) (*room, []*player) {
+	room := &room{
+		Players:      make(map[uuid.UUID]*player),
+		Settings:     RoomSettings{PlayerLimit: 10, DrawingTimeAllowed: 60, GameMode: mode},
+		ChatMessages: make([]ChatMessage, 0),
+		drawingQueue: make([]uuid.UUID, 0),
+		scheduler:    NewGameScheduler(),
+		currentState: NewWaitingState(),
+	}
+	start := time.Now()
+	players := make([]*player, n)
+	for i := range players {
+		role := RoomRolePlayer
+		if i == 0 {
+			role = RoomRoleHost
+		}
+		players[i] = &player{
+			ID:       uuid.New(),
+			Username: string(rune('A' + i)),
+			RoomRole: role,
+			GameRole: GameRoleGuessing,
+			joinedAt: start.Add(time.Duration(i) * time.Second),
+			remoteIP: fmt.Sprintf("203.0.113.%d", i+1),
+		}
+		// The client isn't run, but it can still be closed when a player is removed
+		players[i].client = NewClient(nil, nil, players[i])
+		players[i].client.cancel = func(error) {}
+		room.Players[players[i].ID] = players[i]
+		room.drawingQueue = append(room.drawingQueue, players[i].ID)
+	}
+	return room, players
+}
+
 func TestResetPlayerStates(t *testing.T) {
 	room := &room{
 		Players:      make(map[uuid.UUID]*player),
@@ -68,3 +105,127 @@ func TestHandleChatMessageBounding(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckCapacity_SpectatorsHaveTheirOwnLimit(t *testing.T) {
+	room := &room{
+		Players:  make(map[uuid.UUID]*player),
+		Settings: RoomSettings{PlayerLimit: 2},
+	}
+	for i := 0; i < 2; i++ {
+		p := &player{ID: uuid.New(), RoomRole: RoomRolePlayer}
+		room.Players[p.ID] = p
+	}
+
+	if err := room.checkCapacity(&player{RoomRole: RoomRolePlayer}); err != ErrRoomFull {
+		t.Errorf("expected %v for a player joining a full room, got %v", ErrRoomFull, err)
+	}
+	if err := room.checkCapacity(&player{RoomRole: RoomRoleSpectator}); err != nil {
+		t.Errorf("expected spectators to be able to watch a full room, got %v", err)
+	}
+
+	for i := 0; i < DefaultConfig().Rooms.MaxSpectators; i++ {
+		p := &player{ID: uuid.New(), RoomRole: RoomRoleSpectator}
+		room.Players[p.ID] = p
+	}
+	if err := room.checkCapacity(&player{RoomRole: RoomRoleSpectator}); err != ErrSpectatorsFull {
+		t.Errorf("expected %v once the spectator limit is reached, got %v", ErrSpectatorsFull, err)
+	}
+}
+
+func TestSpectatorChat_OnlyReachesSpectators(t *testing.T) {
+	p := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, client: NewClient(nil, nil, nil)}
+	spectator := &player{ID: uuid.New(), RoomRole: RoomRoleSpectator, client: NewClient(nil, nil, nil)}
+	room := &room{
+		Players:       map[uuid.UUID]*player{p.ID: p, spectator.ID: spectator},
+		ChatMessages:  make([]ChatMessage, 0),
+		SpectatorChat: make([]ChatMessage, 0),
+		currentState:  NewWaitingState(),
+	}
+
+	room.dispatch(&Command{Type: ChatMessageCmd, Payload: "go blue!", Player: spectator})
+
+	if len(room.ChatMessages) != 0 || len(room.SpectatorChat) != 1 {
+		t.Fatalf("expected the message in spectator chat only, got %d player and %d spectator messages",
+			len(room.ChatMessages), len(room.SpectatorChat))
+	}
+	if events := drainEvents(p.client); len(events) != 0 {
+		t.Errorf("expected players not to see spectator chat, got %s", events[0].Type)
+	}
+	if events := drainEvents(spectator.client); len(events) != 1 || events[0].Type != NewSpectatorChatEvt {
+		t.Error("expected spectators to get the message")
+	}
+}
+
+func TestSpectators_DontBlockEveryoneGuessed(t *testing.T) {
+	drawer := &player{ID: uuid.New(), GameRole: GameRoleDrawing, RoomRole: RoomRoleHost, client: NewClient(nil, nil, nil)}
+	guesser := &player{ID: uuid.New(), GameRole: GameRoleGuessing, RoomRole: RoomRolePlayer, client: NewClient(nil, nil, nil)}
+	spectator := &player{ID: uuid.New(), GameRole: GameRoleGuessing, RoomRole: RoomRoleSpectator, client: NewClient(nil, nil, nil)}
+
+	state := NewDrawingState(Word{Value: "cat", Difficulty: WordDifficultyEasy}).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	room := &room{
+		Players:        map[uuid.UUID]*player{drawer.ID: drawer, guesser.ID: guesser, spectator.ID: spectator},
+		ChatMessages:   make([]ChatMessage, 0),
+		SpectatorChat:  make([]ChatMessage, 0),
+		currentDrawers: []*player{drawer},
+		currentState:   state,
+		scheduler:      NewGameScheduler(),
+	}
+
+	// Spectators can't score, even if they know the word
+	room.dispatch(&Command{Type: ChatMessageCmd, Payload: "cat", Player: spectator})
+	if state.pointsAwarded[spectator.ID] != 0 {
+		t.Error("expected spectators not to score")
+	}
+
+	// The only real guesser getting it ends the round
+	room.dispatch(&Command{Type: ChatMessageCmd, Payload: "cat", Player: guesser})
+	if !state.isDrawingPhaseOver() {
+		t.Error("expected the round to end once every player guessed")
+	}
+
+	// Spectators never end up in the drawing queue
+	room.fillDrawingQueue()
+	for _, id := range room.drawingQueue {
+		if id == spectator.ID {
+			t.Error("expected spectators not to be in the drawing queue")
+		}
+	}
+}
+
+func TestNextHost(t *testing.T) {
+	now := time.Now()
+	host := &player{ID: uuid.New(), RoomRole: RoomRoleHost, joinedAt: now.Add(-time.Hour), client: NewClient(nil, nil, nil)}
+	oldest := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, joinedAt: now.Add(-30 * time.Minute), client: NewClient(nil, nil, nil)}
+	newest := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, joinedAt: now, client: NewClient(nil, nil, nil)}
+	away := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, joinedAt: now.Add(-45 * time.Minute)}
+	watcher := &player{ID: uuid.New(), RoomRole: RoomRoleSpectator, joinedAt: now.Add(-2 * time.Hour), client: NewClient(nil, nil, nil)}
+
+	tests := []struct {
+		name    string
+		players []*player
+		want    *player
+	}{
+		{name: "longest connected player takes over", players: []*player{host, newest, oldest}, want: oldest},
+		{name: "connected players are preferred", players: []*player{host, newest, away}, want: newest},
+		{name: "disconnected player if nobody else is left", players: []*player{host, away}, want: away},
+		{name: "spectators never take over", players: []*player{host, watcher, newest}, want: newest},
+		{name: "nobody to take over", players: []*player{host, watcher}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := &room{Players: make(map[uuid.UUID]*player)}
+			for _, p := range tt.players {
+				room.Players[p.ID] = p
+			}
+
+			// Map iteration order is random, so run it a few times
+			for i := 0; i < 10; i++ {
+				if got := room.nextHost(host.ID); got != tt.want {
+					t.Fatalf("expected %v to take over, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
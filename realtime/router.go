@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 )
 
 type contextKey string
@@ -47,6 +49,7 @@ func logMiddleware(next http.Handler) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 				"remote_addr", r.RemoteAddr,
+				"client_ip", getClientIP(r.Context()),
 				"user_agent", r.UserAgent(),
 				"duration", time.Since(start),
 				"request_id", requestID,
@@ -56,12 +59,12 @@ func logMiddleware(next http.Handler) http.Handler {
 }
 
 // Clients use this endpoint to create and join a new room.
-func host(rm RoomManager) http.HandlerFunc {
+func host(rm RoomManager, upgrader *websocket.Upgrader) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		requestID := getRequestID(r.Context())
 
 		// Upgrade the HTTP connection to a WebSocket connection
-		conn, err := UpgradeConnection(w, r)
+		conn, err := UpgradeConnection(upgrader, w, r)
 		if err != nil {
 			slog.Warn("Failed to upgrade to websocket connection",
 				"error", err,
@@ -86,6 +89,7 @@ func host(rm RoomManager) http.HandlerFunc {
 
 		// Connect the player to the newly created room
 		player := NewPlayer(RoomRoleHost)
+		player.remoteIP = getClientIP(r.Context())
 		err = room.Connect(conn, player)
 		if err != nil {
 			slog.Warn("Failed to connect player to room",
@@ -105,8 +109,9 @@ func host(rm RoomManager) http.HandlerFunc {
 	}
 }
 
-// Clients use this endpoint to join an existing room.
-func join(rm RoomManager) http.HandlerFunc {
+// Clients use this endpoint to join an existing room,
+// either as a player or as a spectator.
+func join(rm RoomManager, upgrader *websocket.Upgrader, role RoomRole) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		requestID := getRequestID(r.Context())
 
@@ -118,7 +123,7 @@ func join(rm RoomManager) http.HandlerFunc {
 		}
 
 		// Upgrade the HTTP connection to a WebSocket connection
-		conn, err := UpgradeConnection(w, r)
+		conn, err := UpgradeConnection(upgrader, w, r)
 		if err != nil {
 			slog.Warn("Failed to upgrade to websocket connection",
 				"error", err,
@@ -140,8 +145,154 @@ func join(rm RoomManager) http.HandlerFunc {
 			return
 		}
 
+		// Reattach the client to their existing player if they are resuming a session
+		if token := r.URL.Query().Get("resume"); token != "" {
+			err = room.Resume(conn, token, getClientIP(r.Context()))
+			if err != nil {
+				slog.Warn("Failed to resume player session",
+					"roomId", room.Code(),
+					"error", err,
+					"request_id", requestID,
+				)
+				CloseConnectionWithReason(conn, err.Error())
+				return
+			}
+			slog.Info("Player resumed session",
+				"roomId", room.Code(),
+				"request_id", requestID,
+			)
+			return
+		}
+
+		// Players need the password if the room has one
+		err = room.CheckPassword(getClientIP(r.Context()), r.URL.Query().Get("password"))
+		if err != nil {
+			slog.Warn("Player failed the room password check",
+				"roomId", room.Code(),
+				"error", err,
+				"request_id", requestID,
+			)
+			CloseConnectionWithReason(conn, err.Error())
+			return
+		}
+
 		// Connect the player to the room
-		player := NewPlayer(RoomRolePlayer)
+		player := NewPlayer(role)
+		player.remoteIP = getClientIP(r.Context())
+		err = room.Connect(conn, player)
+		if err != nil {
+			slog.Warn("Failed to connect player to room",
+				"roomId", room.Code(),
+				"playerId", player.ID,
+				"error", err,
+				"request_id", requestID,
+			)
+			CloseConnectionWithReason(conn, err.Error())
+			return
+		}
+		slog.Info("Player connected to room",
+			"roomId", room.Code(),
+			"playerId", player.ID,
+			"role", role,
+			"request_id", requestID,
+		)
+	}
+}
+
+// Clients use this endpoint to list public rooms in the room browser.
+func listRooms(rm RoomManager, origins *originChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The frontend is served from another origin
+		if origin := r.Header.Get("Origin"); origin != "" && origins.allowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rm.PublicRooms()); err != nil {
+			slog.Warn("Failed to write public rooms",
+				"error", err,
+				"request_id", getRequestID(r.Context()),
+			)
+		}
+	}
+}
+
+// Clients use this endpoint to get into a game without a room code.
+// The player is put in the best open public room, or a new public room
+// if there isn't one they can join.
+//
+// Starting a new room counts against the client's room creation limit,
+// joining one doesn't, so the limit is checked here rather than by a middleware.
+func quickplay(rm RoomManager, upgrader *websocket.Upgrader, limits *ipLimiter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := getRequestID(r.Context())
+
+		// Upgrade the HTTP connection to a WebSocket connection
+		conn, err := UpgradeConnection(upgrader, w, r)
+		if err != nil {
+			slog.Warn("Failed to upgrade to websocket connection",
+				"error", err,
+				"request_id", requestID,
+			)
+			http.Error(w, "Failed to upgrade to websocket connection", http.StatusInternalServerError)
+			return
+		}
+
+		// Rooms can fill up between listing them and joining,
+		// so we move on to the next one when that happens
+		for _, public := range rm.PublicRooms() {
+			// Quick play players don't have the password
+			if public.Settings.HasPassword {
+				continue
+			}
+
+			room, err := rm.Room(public.Code)
+			if err != nil {
+				continue
+			}
+
+			player := NewPlayer(RoomRolePlayer)
+			player.remoteIP = getClientIP(r.Context())
+			err = room.Connect(conn, player)
+			if err != nil {
+				slog.Debug("Failed to quick play into room, trying the next one",
+					"roomId", room.Code(),
+					"error", err,
+					"request_id", requestID,
+				)
+				continue
+			}
+			slog.Info("Player connected to room",
+				"roomId", room.Code(),
+				"playerId", player.ID,
+				"quickplay", true,
+				"request_id", requestID,
+			)
+			return
+		}
+
+		// There's no room to join, so we start a new public one
+		if !limits.allowRoomCreation(getClientIP(r.Context())) {
+			slog.Warn("Too many rooms created by client",
+				"request_id", requestID,
+			)
+			CloseConnectionWithReason(conn, ErrTooManyRooms.Error())
+			return
+		}
+		room, err := rm.RegisterPublic()
+		if err != nil {
+			slog.Warn("Failed to register room",
+				"error", err,
+				"request_id", requestID,
+			)
+			CloseConnectionWithReason(conn, ErrRoomNotFound.Error())
+			return
+		}
+		go room.Run(rm)
+
+		player := NewPlayer(RoomRoleHost)
+		player.remoteIP = getClientIP(r.Context())
 		err = room.Connect(conn, player)
 		if err != nil {
 			slog.Warn("Failed to connect player to room",
@@ -156,19 +307,29 @@ func join(rm RoomManager) http.HandlerFunc {
 		slog.Info("Player connected to room",
 			"roomId", room.Code(),
 			"playerId", player.ID,
+			"quickplay", true,
 			"request_id", requestID,
 		)
 	}
 }
 
-type HTTPConfig struct {
-	Host string
-	Port string
+// Serves a copy of the config the server is running with, without secrets.
+// It's only registered when debug endpoints are turned on.
+func debugConfig(cfg *Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(cfg.redacted()); err != nil {
+			slog.Warn("Failed to write config",
+				"error", err,
+				"request_id", getRequestID(r.Context()),
+			)
+		}
+	}
 }
 
 func NewServer(
 	rm RoomManager,
-	cfg *HTTPConfig,
+	cfg *Config,
 ) *http.Handler {
 	mux := http.NewServeMux()
 
@@ -176,21 +337,34 @@ func NewServer(
 		w.WriteHeader(http.StatusOK)
 	})
 
-	mux.Handle("/host", host(rm))
-	mux.Handle("/join/{code}", join(rm))
-	var handler http.Handler = requestIDMiddleware(logMiddleware(mux))
+	// Limits are checked before the connection is upgraded, see ip_limit.go
+	limits := newIPLimiter(cfg.IPLimits)
+
+	origins := newOriginChecker(cfg.Server)
+	upgrader := newUpgrader(origins)
+
+	mux.Handle("/host", limits.connectionLimitMiddleware(limits.roomCreationLimitMiddleware(host(rm, upgrader))))
+	mux.Handle("/join/{code}", limits.connectionLimitMiddleware(join(rm, upgrader, RoomRolePlayer)))
+	mux.Handle("/spectate/{code}", limits.connectionLimitMiddleware(join(rm, upgrader, RoomRoleSpectator)))
+	mux.Handle("GET /rooms", listRooms(rm, origins))
+	mux.Handle("/quickplay", limits.connectionLimitMiddleware(quickplay(rm, upgrader, limits)))
+	mux.Handle("GET /metrics", metricsHandler())
+	if cfg.Server.DebugEndpoints {
+		mux.Handle("GET /debug/config", debugConfig(cfg))
+	}
+	var handler http.Handler = requestIDMiddleware(limits.clientIPMiddleware(logMiddleware(mux)))
 	return &handler
 }
 
 func ServeHTTP(
 	ctx context.Context,
-	cfg *HTTPConfig,
+	cfg *Config,
 	rm RoomManager,
 ) error {
 	srv := NewServer(rm, cfg)
 
 	httpServer := &http.Server{
-		Addr:    cfg.Host + ":" + cfg.Port,
+		Addr:    cfg.Server.Host + ":" + cfg.Server.Port,
 		Handler: *srv,
 	}
 
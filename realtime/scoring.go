@@ -4,6 +4,7 @@ import (
 	"math"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -23,8 +24,101 @@ const (
 
 	// Maximum streak bonus
 	MAX_STREAK = 10
+
+	// Share of the points a guess is still worth as the timer runs out in timed scoring
+	MIN_TIMED_SHARE = 0.2
+)
+
+// ScoringMode decides how the points for a correct guess are worked out
+type ScoringMode string
+
+const (
+	// Points go down with each player who already guessed the word
+	ScoringModeClassic ScoringMode = "classic"
+	// Points go down as the drawing time runs out
+	ScoringModeTimed ScoringMode = "timed"
 )
 
+// Scorer decides how many points players get for each drawing. Every
+// ScoringMode has its own, so new ways of scoring only need a Scorer
+// added to scorers.
+type Scorer interface {
+	// GuessPoints returns the points for a correct guess, for the
+	// guesser and for each player who drew the word
+	GuessPoints(guess GuessContext) (guesserPoints, drawerPoints int)
+
+	// BonusPoints returns the bonus a player gets once the drawing ends
+	BonusPoints(bonus BonusContext) int
+}
+
+// GuessContext describes a correct guess
+type GuessContext struct {
+	// Players who can guess the word, not counting the drawers
+	Guessers int
+	// Players who guessed the word before this one
+	CorrectGuesses int
+
+	Difficulty WordDifficulty
+
+	// Time left until the drawing ends and the full drawing time
+	TimeLeft    time.Duration
+	DrawingTime time.Duration
+
+	// Letters of the word revealed as hints so far
+	HintsRevealed int
+
+	// The guesser's ranking (1-based) before the points are added
+	Rank int
+}
+
+// BonusContext describes a player at the end of a drawing
+type BonusContext struct {
+	// The player's ranking (1-based) and the number of players ranked
+	Rank         int
+	TotalPlayers int
+
+	// Drawings in a row the player guessed, including this one
+	Streak int
+}
+
+var scorers = map[ScoringMode]Scorer{
+	ScoringModeClassic: classicScorer{},
+	ScoringModeTimed:   timedScorer{},
+}
+
+// Returns the scorer for the scoring mode, falling back to the classic one
+func scorerFor(mode ScoringMode) Scorer {
+	if scorer, ok := scorers[mode]; ok {
+		return scorer
+	}
+	return scorers[ScoringModeClassic]
+}
+
+// Awards the classic streak bonus, the built in scorers share it
+type streakBonusScorer struct{}
+
+func (streakBonusScorer) BonusPoints(bonus BonusContext) int {
+	return StreakBonus(bonus.Rank, bonus.TotalPlayers, bonus.Streak)
+}
+
+// Scores guesses by the order they came in, see GuessPoints
+type classicScorer struct {
+	streakBonusScorer
+}
+
+func (classicScorer) GuessPoints(guess GuessContext) (int, int) {
+	return GuessPoints(guess.Guessers, guess.CorrectGuesses, guess.Difficulty)
+}
+
+// Scores guesses by how much time was left, see TimedGuessPoints
+type timedScorer struct {
+	streakBonusScorer
+}
+
+func (timedScorer) GuessPoints(guess GuessContext) (int, int) {
+	return TimedGuessPoints(guess.Guessers, guess.TimeLeft, guess.DrawingTime, guess.Difficulty)
+}
+
 // GuessPoints calculates the points for both guesser and drawer using exponential decay
 //
 // Parameters:
@@ -36,18 +130,6 @@ const (
 // - guesserScore: points awarded to the current guesser
 // - drawerScore: points awarded to the drawer for this guess
 func GuessPoints(maxGuessers, correctGuesses int, wordDifficulty WordDifficulty) (guesserScore, drawerScore int) {
-	drawerSharePercent := EASY_WORD_SHARE
-
-	// Adjust the drawer's share based on the word difficulty
-	switch wordDifficulty {
-	case WordDifficultyEasy:
-		drawerSharePercent = EASY_WORD_SHARE
-	case WordDifficultyMedium, WordDifficultyCustom:
-		drawerSharePercent = MEDIUM_WORD_SHARE
-	case WordDifficultyHard:
-		drawerSharePercent = HARD_WORD_SHARE
-	}
-
 	maxPoints := BASE_POINTS + POINTS_PER_PLAYER*maxGuessers
 
 	// Calculate position multiplier (earlier guessers get larger share)
@@ -55,11 +137,53 @@ func GuessPoints(maxGuessers, correctGuesses int, wordDifficulty WordDifficulty)
 	guesserPoints := float32(maxPoints) * positionMultiplier
 
 	// Calculate the drawer's share of the points
-	drawerPoints := guesserPoints * float32(drawerSharePercent)
+	drawerPoints := guesserPoints * drawerShare(wordDifficulty)
 
 	return int(guesserPoints), int(drawerPoints)
 }
 
+// TimedGuessPoints calculates the points for both guesser and drawer based on
+// how much of the drawing time was left when the guess came in
+//
+// Parameters:
+// - maxGuessers: total number of players who can guess (excluding drawer)
+// - timeLeft: time remaining until the drawing phase ends
+// - drawingTime: the full drawing time of the round
+// - wordDifficulty: the difficulty of the word being guessed
+//
+// Returns:
+// - guesserScore: points awarded to the current guesser
+// - drawerScore: points awarded to the drawer for this guess
+func TimedGuessPoints(maxGuessers int, timeLeft, drawingTime time.Duration, wordDifficulty WordDifficulty) (guesserScore, drawerScore int) {
+	maxPoints := BASE_POINTS + POINTS_PER_PLAYER*maxGuessers
+
+	// Share of the drawing time left, guesses right at the buzzer are
+	// still worth the minimum share
+	remaining := float32(0)
+	if drawingTime > 0 {
+		remaining = float32(min(max(timeLeft, 0), drawingTime)) / float32(drawingTime)
+	}
+	timeMultiplier := MIN_TIMED_SHARE + (1-MIN_TIMED_SHARE)*remaining
+	guesserPoints := float32(maxPoints) * timeMultiplier
+
+	// Calculate the drawer's share of the points
+	drawerPoints := guesserPoints * drawerShare(wordDifficulty)
+
+	return int(guesserPoints), int(drawerPoints)
+}
+
+// Returns the drawer's share of a guesser's points, harder words are worth more
+func drawerShare(wordDifficulty WordDifficulty) float32 {
+	switch wordDifficulty {
+	case WordDifficultyMedium, WordDifficultyCustom:
+		return MEDIUM_WORD_SHARE
+	case WordDifficultyHard:
+		return HARD_WORD_SHARE
+	default:
+		return EASY_WORD_SHARE
+	}
+}
+
 // StreakBonus calculates the points for a streak bonus with exponential decay for top players
 //
 // Parameters:
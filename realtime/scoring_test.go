@@ -0,0 +1,169 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGuessPoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxGuessers    int
+		correctGuesses int
+		difficulty     WordDifficulty
+		wantGuesser    int
+		wantDrawer     int
+	}{
+		{name: "first of four, easy", maxGuessers: 4, correctGuesses: 0, difficulty: WordDifficultyEasy, wantGuesser: 400, wantDrawer: 60},
+		{name: "last of four, easy", maxGuessers: 4, correctGuesses: 3, difficulty: WordDifficultyEasy, wantGuesser: 100, wantDrawer: 15},
+		{name: "second of two, medium", maxGuessers: 2, correctGuesses: 1, difficulty: WordDifficultyMedium, wantGuesser: 175, wantDrawer: 61},
+		{name: "custom words pay like medium", maxGuessers: 2, correctGuesses: 1, difficulty: WordDifficultyCustom, wantGuesser: 175, wantDrawer: 61},
+		{name: "only guesser, hard", maxGuessers: 1, correctGuesses: 0, difficulty: WordDifficultyHard, wantGuesser: 325, wantDrawer: 195},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guesser, drawer := GuessPoints(tt.maxGuessers, tt.correctGuesses, tt.difficulty)
+			if guesser != tt.wantGuesser || drawer != tt.wantDrawer {
+				t.Errorf("GuessPoints() = (%d, %d), want (%d, %d)", guesser, drawer, tt.wantGuesser, tt.wantDrawer)
+			}
+		})
+	}
+}
+
+func TestTimedGuessPoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxGuessers int
+		timeLeft    time.Duration
+		drawingTime time.Duration
+		difficulty  WordDifficulty
+		wantGuesser int
+		wantDrawer  int
+	}{
+		{name: "right away", maxGuessers: 4, timeLeft: 90 * time.Second, drawingTime: 90 * time.Second, difficulty: WordDifficultyEasy, wantGuesser: 400, wantDrawer: 60},
+		{name: "halfway", maxGuessers: 4, timeLeft: 45 * time.Second, drawingTime: 90 * time.Second, difficulty: WordDifficultyEasy, wantGuesser: 240, wantDrawer: 36},
+		{name: "at the buzzer", maxGuessers: 4, timeLeft: 0, drawingTime: 90 * time.Second, difficulty: WordDifficultyEasy, wantGuesser: 80, wantDrawer: 12},
+		{name: "after the buzzer", maxGuessers: 4, timeLeft: -time.Second, drawingTime: 90 * time.Second, difficulty: WordDifficultyEasy, wantGuesser: 80, wantDrawer: 12},
+		{name: "more time left than allowed", maxGuessers: 4, timeLeft: 2 * time.Minute, drawingTime: 90 * time.Second, difficulty: WordDifficultyEasy, wantGuesser: 400, wantDrawer: 60},
+		{name: "halfway, hard", maxGuessers: 1, timeLeft: 30 * time.Second, drawingTime: time.Minute, difficulty: WordDifficultyHard, wantGuesser: 195, wantDrawer: 117},
+		{name: "no drawing time", maxGuessers: 1, timeLeft: 0, drawingTime: 0, difficulty: WordDifficultyMedium, wantGuesser: 65, wantDrawer: 22},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guesser, drawer := TimedGuessPoints(tt.maxGuessers, tt.timeLeft, tt.drawingTime, tt.difficulty)
+			if guesser != tt.wantGuesser || drawer != tt.wantDrawer {
+				t.Errorf("TimedGuessPoints() = (%d, %d), want (%d, %d)", guesser, drawer, tt.wantGuesser, tt.wantDrawer)
+			}
+		})
+	}
+}
+
+func TestScorerFor(t *testing.T) {
+	guess := GuessContext{Guessers: 4, CorrectGuesses: 3, Difficulty: WordDifficultyEasy, TimeLeft: 90 * time.Second, DrawingTime: 90 * time.Second}
+
+	tests := []struct {
+		mode        ScoringMode
+		wantGuesser int
+	}{
+		{mode: ScoringModeClassic, wantGuesser: 100},
+		{mode: ScoringModeTimed, wantGuesser: 400},
+		{mode: "unknown", wantGuesser: 100},
+	}
+	for _, tt := range tests {
+		if got, _ := scorerFor(tt.mode).GuessPoints(guess); got != tt.wantGuesser {
+			t.Errorf("%s: expected %d points, got %d", tt.mode, tt.wantGuesser, got)
+		}
+	}
+
+	bonus := BonusContext{Rank: 2, TotalPlayers: 4, Streak: 3}
+	for mode, scorer := range scorers {
+		if got, want := scorer.BonusPoints(bonus), StreakBonus(2, 4, 3); got != want {
+			t.Errorf("%s: expected a streak bonus of %d, got %d", mode, want, got)
+		}
+	}
+}
+
+func TestWaitingState_HandleCommand_ChangeRoomSettings_ScoringMode(t *testing.T) {
+	room, players := newTestRoom(GameModeClassic, 2)
+	change := func(scoringMode string) error {
+		payload := map[string]interface{}{
+			"playerLimit":        6,
+			"drawingTimeAllowed": 90,
+			"totalRounds":        3,
+			"wordDifficulty":     string(WordDifficultyAll),
+			"gameMode":           string(GameModeClassic),
+			"wordBank":           string(WordBankDefault),
+		}
+		if scoringMode != "" {
+			payload["scoringMode"] = scoringMode
+		}
+		return room.currentState.HandleCommand(room, &Command{Type: ChangeRoomSettingsCmd, Player: players[0], Payload: payload})
+	}
+
+	if err := change(string(ScoringModeTimed)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if room.Settings.ScoringMode != ScoringModeTimed {
+		t.Errorf("expected timed scoring, got %q", room.Settings.ScoringMode)
+	}
+
+	// Clients that don't know about scoring modes get classic scoring
+	if err := change(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if room.Settings.ScoringMode != ScoringModeClassic {
+		t.Errorf("expected classic scoring, got %q", room.Settings.ScoringMode)
+	}
+
+	if err := change("invalid"); errorCodeOf(err) != ErrCodeInvalidSettings {
+		t.Errorf("expected invalid scoring modes to be rejected, got %v", err)
+	}
+}
+
+// Gives every guess the same points and records what it was asked about
+type flatScorer struct {
+	guesses []GuessContext
+}
+
+func (s *flatScorer) GuessPoints(guess GuessContext) (int, int) {
+	s.guesses = append(s.guesses, guess)
+	return 100, 50
+}
+
+func (s *flatScorer) BonusPoints(BonusContext) int {
+	return 0
+}
+
+func TestDrawingState_UsesRoomScorer(t *testing.T) {
+	const flat ScoringMode = "flat"
+	scorer := &flatScorer{}
+	scorers[flat] = scorer
+	t.Cleanup(func() { delete(scorers, flat) })
+
+	room, players := newTestRoom(GameModeClassic, 3)
+	room.Settings.ScoringMode = flat
+	drawer, guesser := players[0], players[1]
+	room.currentDrawers = []*player{drawer}
+
+	state := NewDrawingState(Word{Value: "apple", Difficulty: WordDifficultyMedium}).(*DrawingState)
+	room.currentState = state
+	state.Enter(room)
+	state.applyHint()
+
+	if err := state.HandleCommand(room, &Command{Type: ChatMessageCmd, Player: guesser, Payload: "apple"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if guesser.Score != 100 || drawer.Score != 50 {
+		t.Errorf("expected the scorer's points, got %d and %d", guesser.Score, drawer.Score)
+	}
+
+	if len(scorer.guesses) != 1 {
+		t.Fatalf("expected the scorer to be asked once, got %d", len(scorer.guesses))
+	}
+	guess := scorer.guesses[0]
+	if guess.Guessers != 2 || guess.CorrectGuesses != 0 || guess.Difficulty != WordDifficultyMedium || guess.HintsRevealed != 1 || guess.DrawingTime != 60*time.Second {
+		t.Errorf("unexpected guess context: %+v", guess)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Resume tokens let a player whose websocket dropped (ex. a phone going to sleep)
+// reconnect to the same player slot instead of joining as a brand new player.
+//
+// When a player's connection is lost unexpectedly, the room keeps them around in a
+// disconnected state for Sessions.ResumeGracePeriod. If they reconnect through
+// /join/{code}?resume=<token> before the grace period ends, the room attaches the new
+// connection to the existing player and replays the current game state to them.
+
+const (
+	// How often to check for disconnected players whose grace period has expired
+	RESUME_TICK = 1 * time.Second
+)
+
+var (
+	ErrInvalidResumeToken = errors.New("ErrInvalidResumeToken")
+	ErrSessionExpired     = errors.New("ErrSessionExpired")
+	ErrSessionReplaced    = errors.New("ErrSessionReplaced")
+)
+
+// Close causes the server uses to deliberately remove a player.
+// Players disconnected for one of these reasons are not held for resuming.
+var nonResumableCauses = []error{
+	ErrPlayerIdle,
+	ErrSessionReplaced,
+	ErrKicked,
+	ErrBanned,
+	ErrVoteKicked,
+}
+
+// PlayerSession is sent to a player when they join or resume so they know
+// who they are and how to get back in if their connection drops.
+type PlayerSession struct {
+	ID          uuid.UUID `json:"id"`
+	ResumeToken string    `json:"resumeToken"`
+}
+
+func NewPlayerSession(key []byte, roomID string, playerID uuid.UUID) PlayerSession {
+	return PlayerSession{
+		ID:          playerID,
+		ResumeToken: signResumeToken(key, roomID, playerID),
+	}
+}
+
+// Generates a random key for signing resume tokens.
+func randomSecret() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		// This should never happen
+		panic(err)
+	}
+	return b
+}
+
+// Signs a token that identifies a player in a room.
+//
+// The token is in the form base64(roomID:playerID).base64(hmac)
+func signResumeToken(key []byte, roomID string, playerID uuid.UUID) string {
+	payload := []byte(roomID + ":" + playerID.String())
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(resumeTokenMAC(key, payload))
+}
+
+// Verifies a resume token and returns the room and player it was issued for.
+func verifyResumeToken(key []byte, token string) (roomID string, playerID uuid.UUID, err error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", uuid.Nil, ErrInvalidResumeToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", uuid.Nil, ErrInvalidResumeToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil {
+		return "", uuid.Nil, ErrInvalidResumeToken
+	}
+
+	// Constant time comparison so the signature can't be guessed byte by byte
+	if !hmac.Equal(mac, resumeTokenMAC(key, payload)) {
+		return "", uuid.Nil, ErrInvalidResumeToken
+	}
+
+	roomID, rawPlayerID, ok := strings.Cut(string(payload), ":")
+	if !ok {
+		return "", uuid.Nil, ErrInvalidResumeToken
+	}
+	playerID, err = uuid.Parse(rawPlayerID)
+	if err != nil {
+		return "", uuid.Nil, ErrInvalidResumeToken
+	}
+
+	return roomID, playerID, nil
+}
+
+func resumeTokenMAC(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Checks if a player disconnected with the given cause should be held
+// in the room so they can resume their session.
+func isResumableCause(cause error) bool {
+	for _, err := range nonResumableCauses {
+		if errors.Is(cause, err) {
+			return false
+		}
+	}
+
+	// The client closed the connection on purpose, they left the room
+	var closeErr *websocket.CloseError
+	if errors.As(cause, &closeErr) {
+		return closeErr.Code != websocket.CloseNormalClosure &&
+			closeErr.Code != websocket.CloseNoStatusReceived
+	}
+
+	return true
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+func TestResumeToken(t *testing.T) {
+	playerID := uuid.New()
+	key := randomSecret()
+	token := signResumeToken(key, "ABCD", playerID)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:    "valid token",
+			token:   token,
+			wantErr: false,
+		},
+		{
+			name:    "missing signature",
+			token:   token[:len(token)-44],
+			wantErr: true,
+		},
+		{
+			name:    "tampered payload",
+			token:   signResumeToken(key, "WXYZ", playerID)[:10] + token[10:],
+			wantErr: true,
+		},
+		{
+			name:    "signed with another key",
+			token:   signResumeToken(randomSecret(), "ABCD", playerID),
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			token:   "not-a-token",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomID, id, err := verifyResumeToken(key, tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidResumeToken) {
+					t.Errorf("expected ErrInvalidResumeToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if roomID != "ABCD" || id != playerID {
+				t.Errorf("expected ABCD/%s, got %s/%s", playerID, roomID, id)
+			}
+		})
+	}
+}
+
+func TestIsResumableCause(t *testing.T) {
+	tests := []struct {
+		name  string
+		cause error
+		want  bool
+	}{
+		{"network error", io.ErrUnexpectedEOF, true},
+		{"abnormal closure", &websocket.CloseError{Code: websocket.CloseAbnormalClosure}, true},
+		{"going away", &websocket.CloseError{Code: websocket.CloseGoingAway}, true},
+		{"client left", &websocket.CloseError{Code: websocket.CloseNormalClosure}, false},
+		{"idle", ErrPlayerIdle, false},
+		{"replaced", ErrSessionReplaced, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isResumableCause(tt.cause); got != tt.want {
+				t.Errorf("isResumableCause(%v) = %v, want %v", tt.cause, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleDisconnect_HoldsPlayerForResume(t *testing.T) {
+	p1 := &player{ID: uuid.New(), Score: 300, Streak: 2}
+	p2 := &player{ID: uuid.New()}
+
+	_, cancel := context.WithCancelCause(context.Background())
+	room := &room{
+		Players: map[uuid.UUID]*player{
+			p1.ID: p1,
+			p2.ID: p2,
+		},
+		ChatMessages: make([]ChatMessage, 0),
+		drawingQueue: []uuid.UUID{p1.ID, p2.ID},
+		currentState: NewWaitingState(),
+		scheduler:    NewGameScheduler(),
+		cancel:       cancel,
+	}
+	p1.client = NewClient(nil, room, p1)
+	p2.client = NewClient(nil, room, p2)
+
+	dropped := p1.client
+	room.handleDisconnect(&disconnection{client: dropped, cause: io.ErrUnexpectedEOF})
+
+	if _, ok := room.Players[p1.ID]; !ok {
+		t.Fatal("expected player to be held in the room")
+	}
+	if p1.isConnected() {
+		t.Error("expected player to be marked as disconnected")
+	}
+	if p1.Score != 300 || p1.Streak != 2 || len(room.drawingQueue) != 2 {
+		t.Error("expected player state to be preserved")
+	}
+
+	// A stale client from before a resume should not remove the player
+	p1.client = NewClient(nil, room, p1)
+	room.handleDisconnect(&disconnection{client: dropped, cause: io.ErrUnexpectedEOF})
+	if !p1.isConnected() {
+		t.Error("expected stale disconnect to be ignored")
+	}
+
+	// Once the grace period passes the player is removed
+	room.handleDisconnect(&disconnection{client: p1.client, cause: io.ErrUnexpectedEOF})
+	room.removeExpiredSessions(time.Now().Add(DefaultConfig().Sessions.ResumeGracePeriod.Duration + time.Second))
+	if _, ok := room.Players[p1.ID]; ok {
+		t.Error("expected player to be removed after the grace period")
+	}
+	if _, ok := room.Players[p2.ID]; !ok {
+		t.Error("expected connected player to remain in the room")
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Team mode splits the players into two teams that take turns drawing.
+//
+// Only the drawer's teammates can score on a drawing at first. Once the
+// steal delay is up the other team can guess it too, but the drawer only
+// gets points for guesses from their own team. Players still score on their
+// own, a team's score is the sum of its players' scores.
+
+type Team string
+
+const (
+	TeamNone Team = ""
+	TeamRed  Team = "red"
+	TeamBlue Team = "blue"
+)
+
+// Every team in a team game, in the order they're listed
+var Teams = []Team{TeamRed, TeamBlue}
+
+const (
+	ScheduledStealOpen ScheduledEventType = "steal_open"
+
+	// Players each team needs to start a game, one to draw and one to guess
+	MIN_TEAM_PLAYERS = 2
+)
+
+var (
+	ErrNotTeamGame          = &CommandError{ErrCodeNotTeamGame, "the room is not playing in teams"}
+	ErrInvalidTeam          = &CommandError{ErrCodeInvalidTeam, "invalid team"}
+	ErrNotEnoughTeamPlayers = &CommandError{ErrCodeNotEnoughTeamPlayers, fmt.Sprintf("each team needs at least %d players to start the game", MIN_TEAM_PLAYERS)}
+	ErrStealNotOpen         = &CommandError{ErrCodeStealNotOpen, "your team can't steal this drawing yet"}
+)
+
+func isValidTeam(team Team) bool {
+	return slices.Contains(Teams, team)
+}
+
+// TeamAssignment is sent by the host to move a player to another team
+type TeamAssignment struct {
+	PlayerID string `json:"playerId"`
+	Team     Team   `json:"team"`
+}
+
+// TeamStanding is a team's result at the end of a game
+type TeamStanding struct {
+	Team    Team        `json:"team"`
+	Rank    int         `json:"rank"` // Teams with the same score share a rank
+	Score   int         `json:"score"`
+	Players []uuid.UUID `json:"players"`
+}
+
+func (r *room) isTeamGame() bool {
+	return r.Settings.GameMode == GameModeTeams
+}
+
+// Returns the number of players on the team taking part in the current game
+func (r *room) teamSize(team Team) int {
+	count := 0
+	for _, p := range r.Players {
+		if p.Team == team && p.isActive() {
+			count++
+		}
+	}
+	return count
+}
+
+// Returns the team with the fewest players, new players are put on it
+func (r *room) smallestTeam() Team {
+	smallest := Teams[0]
+	for _, team := range Teams[1:] {
+		if r.teamSize(team) < r.teamSize(smallest) {
+			smallest = team
+		}
+	}
+	return smallest
+}
+
+// Puts players on a team when the room switches to team mode and takes them
+// off their teams when it switches back. Returns true if any player changed teams.
+func (r *room) syncTeams() bool {
+	changed := false
+	if !r.isTeamGame() {
+		for _, p := range r.Players {
+			if p.Team != TeamNone {
+				p.Team = TeamNone
+				changed = true
+			}
+		}
+		return changed
+	}
+
+	// Players who joined first are placed first, so the teams come out
+	// the same no matter how the players map is iterated
+	unassigned := make([]*player, 0)
+	for _, p := range r.Players {
+		if p.Team == TeamNone && p.isActive() {
+			unassigned = append(unassigned, p)
+		}
+	}
+	slices.SortFunc(unassigned, byJoinOrder)
+	for _, p := range unassigned {
+		p.Team = r.smallestTeam()
+		changed = true
+	}
+	return changed
+}
+
+// Checks if the teams have enough players to start a game
+func (r *room) checkTeams() error {
+	for _, team := range Teams {
+		if r.teamSize(team) < MIN_TEAM_PLAYERS {
+			return ErrNotEnoughTeamPlayers
+		}
+	}
+	return nil
+}
+
+// Builds the drawing queue so the teams take turns drawing.
+//
+// The team that's behind draws first. Players within a team are sorted by
+// score like in a classic game, and a team with more players has its extra
+// players draw at the end of the round.
+func (r *room) fillTeamDrawingQueue() {
+	scores := r.teamScores()
+	teams := slices.Clone(Teams)
+	slices.SortStableFunc(teams, func(a, b Team) int {
+		return scores[a] - scores[b]
+	})
+
+	queues := make([][]*player, len(teams))
+	for i, team := range teams {
+		for _, p := range r.Players {
+			if p.Team == team && p.isActive() {
+				queues[i] = append(queues[i], p)
+			}
+		}
+		slices.SortFunc(queues[i], func(a, b *player) int {
+			return b.Score - a.Score
+		})
+	}
+
+	r.drawingQueue = make([]uuid.UUID, 0)
+	for turn := 0; ; turn++ {
+		added := false
+		for _, queue := range queues {
+			if turn < len(queue) {
+				r.drawingQueue = append(r.drawingQueue, queue[turn].ID)
+				added = true
+			}
+		}
+		if !added {
+			return
+		}
+	}
+}
+
+// Returns the score of every team
+func (r *room) teamScores() map[Team]int {
+	scores := make(map[Team]int, len(Teams))
+	for _, team := range Teams {
+		scores[team] = 0
+	}
+	for _, p := range r.Players {
+		if isValidTeam(p.Team) {
+			scores[p.Team] += p.Score
+		}
+	}
+	return scores
+}
+
+// Adds up the points awarded to each team's players in a drawing
+func (r *room) teamPointsAwarded(pointsAwarded map[uuid.UUID]int) map[Team]int {
+	awarded := make(map[Team]int, len(Teams))
+	for _, team := range Teams {
+		awarded[team] = 0
+	}
+	for id, points := range pointsAwarded {
+		if p, ok := r.Players[id]; ok && isValidTeam(p.Team) {
+			awarded[p.Team] += points
+		}
+	}
+	return awarded
+}
+
+// Ranks the teams by score, teams with the same score share a rank
+func teamStandings(players map[uuid.UUID]*player) []TeamStanding {
+	standings := make([]TeamStanding, 0, len(Teams))
+	for _, team := range Teams {
+		standing := TeamStanding{Team: team, Players: make([]uuid.UUID, 0)}
+		for _, id := range getSortedPlayersByScore(players) {
+			if p := players[id]; p.Team == team && !p.isSpectator() {
+				standing.Score += p.Score
+				standing.Players = append(standing.Players, p.ID)
+			}
+		}
+		standings = append(standings, standing)
+	}
+
+	slices.SortStableFunc(standings, func(a, b TeamStanding) int {
+		return b.Score - a.Score
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if i > 0 && standings[i].Score == standings[i-1].Score {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+	return standings
+}
+
+// Lets the host move a player to another team in the lobby
+func (state *WaitingState) handleAssignTeam(room *room, cmd *Command) error {
+	if cmd.Player.RoomRole != RoomRoleHost {
+		return ErrWrongRoomRole
+	}
+	if !room.isTeamGame() {
+		return ErrNotTeamGame
+	}
+
+	assignment, err := decodePayload[TeamAssignment](cmd.Payload)
+	if err != nil {
+		return err
+	}
+	if !isValidTeam(assignment.Team) {
+		return ErrInvalidTeam
+	}
+	playerID, err := uuid.Parse(assignment.PlayerID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	target, ok := room.Players[playerID]
+	if !ok || target.isSpectator() {
+		return ErrPlayerNotFound
+	}
+
+	if target.Team == assignment.Team {
+		return nil
+	}
+	target.Team = assignment.Team
+
+	room.broadcast(GameRoleAny,
+		event(SetPlayersEvt, room.Players),
+	)
+	return nil
+}
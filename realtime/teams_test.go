@@ -0,0 +1,226 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRoom_SyncTeams(t *testing.T) {
+	room, _ := newTestRoom(GameModeTeams, 0)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		p := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, joinedAt: start.Add(time.Duration(i) * time.Second)}
+		room.Players[p.ID] = p
+	}
+	spectator := &player{ID: uuid.New(), RoomRole: RoomRoleSpectator}
+	room.Players[spectator.ID] = spectator
+
+	if !room.syncTeams() {
+		t.Fatal("expected players to be put on teams")
+	}
+	if red, blue := room.teamSize(TeamRed), room.teamSize(TeamBlue); red != 3 || blue != 2 {
+		t.Errorf("expected teams of 3 and 2, got %d red and %d blue", red, blue)
+	}
+	if spectator.Team != TeamNone {
+		t.Error("expected spectators not to be put on a team")
+	}
+	if room.syncTeams() {
+		t.Error("expected nothing to change once everyone is on a team")
+	}
+
+	// Switching back to classic takes everyone off their team
+	room.Settings.GameMode = GameModeClassic
+	if !room.syncTeams() {
+		t.Fatal("expected players to be taken off their teams")
+	}
+	for _, p := range room.Players {
+		if p.Team != TeamNone {
+			t.Errorf("expected no team, got %s", p.Team)
+		}
+	}
+}
+
+func TestRoom_FillTeamDrawingQueue(t *testing.T) {
+	room, players := newTestRoom(GameModeTeams, 5)
+	// Teams alternate in join order, so B and D are on blue
+	room.syncTeams()
+	players[1].Score = 100 // Blue is ahead, so red draws first
+
+	room.fillDrawingQueue()
+
+	expected := []Team{TeamRed, TeamBlue, TeamRed, TeamBlue, TeamRed}
+	if len(room.drawingQueue) != len(expected) {
+		t.Fatalf("expected %d drawers, got %d", len(expected), len(room.drawingQueue))
+	}
+	for i, id := range room.drawingQueue {
+		if team := room.Players[id].Team; team != expected[i] {
+			t.Errorf("expected drawer %d to be on team %s, got %s", i+1, expected[i], team)
+		}
+	}
+	if room.drawingQueue[1] != players[1].ID {
+		t.Error("expected players within a team to be sorted by score")
+	}
+}
+
+func TestWaitingState_HandleCommand_AssignTeam(t *testing.T) {
+	room, players := newTestRoom(GameModeTeams, 4)
+	room.syncTeams()
+	red, blue := []*player{players[0], players[2]}, []*player{players[1], players[3]}
+	host := red[0]
+
+	assign := func(p *player, team Team) *Command {
+		return &Command{
+			Type:    AssignTeamCmd,
+			Player:  host,
+			Payload: map[string]interface{}{"playerId": p.ID.String(), "team": string(team)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		cmd     *Command
+		setup   func()
+		wantErr error
+	}{
+		{name: "host moves a player", cmd: assign(blue[0], TeamRed)},
+		{name: "unknown team", cmd: assign(blue[1], Team("green")), wantErr: ErrInvalidTeam},
+		{name: "unknown player", cmd: assign(&player{ID: uuid.New()}, TeamRed), wantErr: ErrPlayerNotFound},
+		{
+			name:    "only the host can move players",
+			cmd:     &Command{Type: AssignTeamCmd, Player: blue[1], Payload: map[string]interface{}{"playerId": red[1].ID.String(), "team": "blue"}},
+			wantErr: ErrWrongRoomRole,
+		},
+		{
+			name:    "not a team game",
+			cmd:     assign(blue[1], TeamRed),
+			setup:   func() { room.Settings.GameMode = GameModeClassic },
+			wantErr: ErrNotTeamGame,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			err := room.currentState.HandleCommand(room, tt.cmd)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if blue[0].Team != TeamRed {
+		t.Errorf("expected the player to be moved to the red team, got %s", blue[0].Team)
+	}
+}
+
+func TestCanStartGame_Teams(t *testing.T) {
+	room, _ := newTestRoom(GameModeTeams, 3)
+	room.syncTeams()
+	if err := canStartGame(room); !errors.Is(err, ErrNotEnoughTeamPlayers) {
+		t.Fatalf("expected %v, got %v", ErrNotEnoughTeamPlayers, err)
+	}
+
+	extra := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, Team: TeamBlue}
+	room.Players[extra.ID] = extra
+	if err := canStartGame(room); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDrawingState_TeamSteals(t *testing.T) {
+	room, players := newTestRoom(GameModeTeams, 4)
+	room.syncTeams()
+	red, blue := []*player{players[0], players[2]}, []*player{players[1], players[3]}
+	drawer, teammate, opponent := red[0], red[1], blue[0]
+	drawer.GameRole = GameRoleDrawing
+	room.currentDrawers = []*player{drawer}
+
+	word := Word{Value: "apple", Difficulty: WordDifficultyHard}
+	state := NewDrawingState(word).(*DrawingState)
+	state.endsAt = time.Now().Add(time.Minute)
+	state.stealAt = time.Now().Add(30 * time.Second)
+	room.currentState = state
+
+	// Guesses go through the room like they would from a client,
+	// returning the error code the guesser was sent if any
+	guess := func(p *player) ErrorCode {
+		drainEvents(p.client)
+		room.dispatch(&Command{Type: ChatMessageCmd, Player: p, Payload: "apple"})
+		for _, evt := range drainEvents(p.client) {
+			if evt.Type == CommandErrorEvt {
+				return evt.Payload.(CommandResult).Code
+			}
+		}
+		return ""
+	}
+
+	// The other team can't score before the steal opens
+	if code := guess(opponent); code != ErrStealNotOpen.Code {
+		t.Fatalf("expected %s, got %q", ErrStealNotOpen.Code, code)
+	}
+	if opponent.Score != 0 {
+		t.Errorf("expected no points before the steal opens, got %d", opponent.Score)
+	}
+	for _, msg := range room.ChatMessages {
+		if msg.Content == "apple" {
+			t.Fatal("expected the answer not to be posted in chat")
+		}
+	}
+
+	// Teammates score right away and the drawer gets a share
+	if code := guess(teammate); code != "" {
+		t.Fatalf("unexpected error: %s", code)
+	}
+	if teammate.Score == 0 || drawer.Score == 0 {
+		t.Errorf("expected the teammate and drawer to score, got %d and %d", teammate.Score, drawer.Score)
+	}
+
+	// Once the steal opens the other team scores, but the drawer doesn't
+	drawerScore := drawer.Score
+	state.stealAt = time.Now()
+	if code := guess(opponent); code != "" {
+		t.Fatalf("unexpected error: %s", code)
+	}
+	if opponent.Score == 0 {
+		t.Error("expected the steal to score")
+	}
+	if drawer.Score != drawerScore {
+		t.Errorf("expected the drawer not to score on a steal, got %d more", drawer.Score-drawerScore)
+	}
+
+	awarded := room.teamPointsAwarded(state.pointsAwarded)
+	if awarded[TeamRed] != teammate.Score+drawer.Score || awarded[TeamBlue] != opponent.Score {
+		t.Errorf("expected points to be added up per team, got %v", awarded)
+	}
+}
+
+func TestTeamStandings(t *testing.T) {
+	room, players := newTestRoom(GameModeTeams, 4)
+	room.syncTeams()
+	red, blue := []*player{players[0], players[2]}, []*player{players[1], players[3]}
+
+	red[0].Score, red[1].Score = 100, 50
+	blue[0].Score, blue[1].Score = 200, 0
+	standings := teamStandings(room.Players)
+	if standings[0].Team != TeamBlue || standings[0].Rank != 1 || standings[0].Score != 200 {
+		t.Errorf("expected blue to win with 200, got %+v", standings[0])
+	}
+	if standings[1].Team != TeamRed || standings[1].Rank != 2 || standings[1].Score != 150 {
+		t.Errorf("expected red second with 150, got %+v", standings[1])
+	}
+	if len(standings[0].Players) != 2 || standings[0].Players[0] != blue[0].ID {
+		t.Errorf("expected the team's players sorted by score, got %v", standings[0].Players)
+	}
+
+	// Tied teams share a rank
+	red[1].Score = 100
+	standings = teamStandings(room.Players)
+	if standings[0].Rank != 1 || standings[1].Rank != 1 {
+		t.Errorf("expected tied teams to share a rank, got %d and %d", standings[0].Rank, standings[1].Rank)
+	}
+}
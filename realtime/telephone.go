@@ -0,0 +1,515 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Telephone mode has every player working at the same time instead of
+// taking turns drawing.
+//
+// Each player starts a chain by writing a prompt. The chains are then passed
+// along, and each step players either draw the text they were handed or
+// describe the drawing they were handed. After one step per player every
+// chain has been through everyone, and the reveal walks through each chain
+// from the first prompt to the last entry.
+
+type TelephoneStepKind string
+
+const (
+	TelephoneStepPrompt      TelephoneStepKind = "prompt"
+	TelephoneStepDrawing     TelephoneStepKind = "drawing"
+	TelephoneStepDescription TelephoneStepKind = "description"
+)
+
+const ScheduledTelephoneReveal ScheduledEventType = "telephone_reveal"
+
+var (
+	ErrNotInTelephoneGame    = &CommandError{ErrCodeWrongGameRole, "player is not part of this game"}
+	ErrAlreadySubmitted      = &CommandError{ErrCodeAlreadySubmitted, "you already handed in this step"}
+	ErrInvalidTelephoneEntry = &CommandError{ErrCodeInvalidTelephoneEntry, fmt.Sprintf("entries must be between 1 and %d characters", MAX_TELEPHONE_TEXT_LENGTH)}
+)
+
+// TelephoneEntry is what a player added to a chain in one step
+type TelephoneEntry struct {
+	PlayerID uuid.UUID         `json:"playerId"`
+	Kind     TelephoneStepKind `json:"kind"`
+	Text     string            `json:"text,omitempty"`
+
+	// Drawings are sent on their own as they're revealed, see TelephoneRevealState
+	Strokes []Stroke `json:"-"`
+}
+
+// TelephoneChain is the prompt a player wrote and everything that
+// was made from it as it got passed along
+type TelephoneChain struct {
+	OwnerID uuid.UUID        `json:"ownerId"`
+	Entries []TelephoneEntry `json:"entries"`
+}
+
+// TelephoneTask tells a player what to do in the current step
+type TelephoneTask struct {
+	Step       int               `json:"step"`
+	TotalSteps int               `json:"totalSteps"`
+	Kind       TelephoneStepKind `json:"kind"`
+	Prompt     string            `json:"prompt,omitempty"` // The text to draw in drawing steps
+	Submitted  bool              `json:"submitted"`
+}
+
+// TelephoneRevealPosition is the entry currently being shown in the reveal
+type TelephoneRevealPosition struct {
+	Chain int `json:"chain"`
+	Entry int `json:"entry"`
+}
+
+// The players and chains of a telephone game, shared by every step
+type telephoneGame struct {
+	// Everyone taking part, in the order chains are passed along
+	players []uuid.UUID
+	chains  []*TelephoneChain
+}
+
+// Starts a telephone game with the room's active players, each starting a chain
+func newTelephoneGame(room *room) *telephoneGame {
+	players := make([]*player, 0, len(room.Players))
+	for _, p := range room.Players {
+		if p.isActive() {
+			players = append(players, p)
+		}
+	}
+	slices.SortFunc(players, byJoinOrder)
+
+	game := &telephoneGame{
+		players: make([]uuid.UUID, len(players)),
+		chains:  make([]*TelephoneChain, len(players)),
+	}
+	for i, p := range players {
+		game.players[i] = p.ID
+		game.chains[i] = &TelephoneChain{OwnerID: p.ID, Entries: make([]TelephoneEntry, 0, len(players))}
+	}
+	return game
+}
+
+// Every chain goes through every player once
+func (g *telephoneGame) steps() int {
+	return len(g.players)
+}
+
+// Returns the chain the player at index i works on in a step.
+// Chains move one player along each step, so nobody gets the same chain twice.
+func (g *telephoneGame) chainFor(i, step int) *TelephoneChain {
+	n := len(g.players)
+	return g.chains[((i-step)%n+n)%n]
+}
+
+// Returns the index of a player in the game, or -1 if they're not taking part
+func (g *telephoneGame) indexOf(id uuid.UUID) int {
+	return slices.Index(g.players, id)
+}
+
+// Chains start with a prompt, then alternate between drawings and descriptions
+func telephoneStepKind(step int) TelephoneStepKind {
+	switch {
+	case step == 0:
+		return TelephoneStepPrompt
+	case step%2 == 1:
+		return TelephoneStepDrawing
+	default:
+		return TelephoneStepDescription
+	}
+}
+
+// A player's own canvas in a drawing step. It has the same limits as the
+// canvas in a classic round, but nobody else sees it until the reveal.
+type telephoneCanvas struct {
+	strokes     []Stroke
+	strokeCount int
+	pointCount  int
+}
+
+func (c *telephoneCanvas) addStroke(stroke Stroke) error {
+	if err := validateStroke(&stroke); err != nil {
+		return err
+	}
+	if c.strokeCount >= MAX_STROKES_PER_ROUND {
+		return ErrTooManyStrokes
+	}
+	if c.pointCount+len(stroke.Points) > MAX_STROKE_POINTS_PER_ROUND {
+		return ErrTooManyStrokePoints
+	}
+	c.strokeCount++
+	c.pointCount += len(stroke.Points)
+	c.strokes = append(c.strokes, stroke)
+	return nil
+}
+
+func (c *telephoneCanvas) addStrokePoints(points ...[]int) error {
+	if len(c.strokes) == 0 {
+		return nil
+	}
+	if c.strokes[len(c.strokes)-1].Type == StrokeTypeFill {
+		return commandErrorf(ErrCodeInvalidStroke, "can't add points to a fill")
+	}
+	if err := sanitizeStrokePoints(points); err != nil {
+		return err
+	}
+	if c.pointCount+len(points) > MAX_STROKE_POINTS_PER_ROUND {
+		return ErrTooManyStrokePoints
+	}
+	c.pointCount += len(points)
+	c.strokes = appendStrokePoints(c.strokes, points...)
+	return nil
+}
+
+// TelephoneState is one step of a telephone game, every player works
+// on their own chain at the same time
+type TelephoneState struct {
+	game   *telephoneGame
+	step   int
+	endsAt time.Time
+
+	// What each player handed in this step
+	submissions map[uuid.UUID]TelephoneEntry
+
+	// Each player's canvas in drawing steps
+	canvases map[uuid.UUID]*telephoneCanvas
+}
+
+func NewTelephoneState(game *telephoneGame, step int) RoomState {
+	return &TelephoneState{
+		game:        game,
+		step:        step,
+		submissions: make(map[uuid.UUID]TelephoneEntry),
+		canvases:    make(map[uuid.UUID]*telephoneCanvas),
+	}
+}
+
+func (state *TelephoneState) kind() TelephoneStepKind {
+	return telephoneStepKind(state.step)
+}
+
+func (state *TelephoneState) Enter(room *room) {
+	duration := room.config().Game.TelephoneWritingDuration.Duration
+	if state.kind() == TelephoneStepDrawing {
+		duration = time.Duration(room.Settings.DrawingTimeAllowed) * time.Second
+	}
+	state.endsAt = time.Now().Add(duration)
+
+	// Everyone draws at the same time, so they all get the drawing role
+	// for the step. That's also what lets their strokes past the rate limit.
+	if state.kind() == TelephoneStepDrawing {
+		for _, id := range state.game.players {
+			if p := room.Players[id]; p != nil {
+				p.GameRole = GameRoleDrawing
+				state.canvases[id] = &telephoneCanvas{}
+			}
+		}
+	}
+
+	room.scheduler.addEvent(ScheduledStateChange, state.endsAt, func() {
+		room.Transition()
+	})
+
+	room.broadcast(GameRoleAny,
+		event(SetCurrentStateEvt, Telephone),
+		event(SetTimerEvt, state.endsAt.UTC()),
+		event(SetPlayersEvt, room.Players),
+		event(SetTelephoneSubmittedEvt, state.submitted()),
+	)
+
+	for i, id := range state.game.players {
+		if p := room.Players[id]; p != nil {
+			state.sendTask(room, p, i)
+		}
+	}
+}
+
+// Adds everyone's entries to their chains and moves on to the next step,
+// or to the reveal once every chain has been through every player
+func (state *TelephoneState) Exit(room *room) {
+	room.scheduler.cancelEvent(ScheduledStateChange)
+
+	for i, id := range state.game.players {
+		entry, ok := state.submissions[id]
+		if !ok {
+			// Whatever a player didn't hand in in time still goes in the chain,
+			// so the chains stay lined up. Drawings are taken as they are.
+			entry = TelephoneEntry{PlayerID: id, Kind: state.kind()}
+			if canvas := state.canvases[id]; canvas != nil {
+				entry.Strokes = canvas.strokes
+			}
+		}
+		chain := state.game.chainFor(i, state.step)
+		chain.Entries = append(chain.Entries, entry)
+	}
+
+	for _, p := range room.Players {
+		p.GameRole = GameRoleGuessing
+	}
+
+	if state.step+1 < state.game.steps() {
+		room.setState(NewTelephoneState(state.game, state.step+1))
+	} else {
+		room.setState(NewTelephoneRevealState(state.game))
+	}
+}
+
+func (state *TelephoneState) HandleCommand(room *room, cmd *Command) error {
+	switch cmd.Type {
+	case TelephoneSubmitCmd:
+		return state.handleSubmit(room, cmd)
+	case AddStrokeCmd, AddStrokePointCmd, AddStrokePointsCmd, ClearStrokesCmd, UndoStrokeCmd:
+		return state.handleCanvasCommand(room, cmd)
+	case PlayerJoinedCmd:
+		return state.handlePlayerJoined(room, cmd)
+	case PlayerLeftCmd:
+		return state.handlePlayerLeft(room, cmd)
+	default:
+		slog.Error("Invalid command for current state", "command", cmd.Type)
+		return ErrInvalidCommand
+	}
+}
+
+// Sends a player what they need to work on in this step
+func (state *TelephoneState) sendTask(room *room, p *player, i int) {
+	chain := state.game.chainFor(i, state.step)
+	task := TelephoneTask{
+		Step:       state.step,
+		TotalSteps: state.game.steps(),
+		Kind:       state.kind(),
+	}
+	_, task.Submitted = state.submissions[p.ID]
+
+	// Players work from the last entry of the chain they were handed
+	var strokes []Stroke
+	if len(chain.Entries) > 0 {
+		last := chain.Entries[len(chain.Entries)-1]
+		task.Prompt = last.Text
+		strokes = last.Strokes
+	}
+	if canvas := state.canvases[p.ID]; canvas != nil {
+		strokes = canvas.strokes
+	}
+	if strokes == nil {
+		strokes = make([]Stroke, 0)
+	}
+
+	p.Send(
+		event(SetTelephoneTaskEvt, task),
+		event(SetStrokesEvt, strokes),
+	)
+}
+
+// Returns the players who already handed in this step
+func (state *TelephoneState) submitted() []uuid.UUID {
+	submitted := make([]uuid.UUID, 0, len(state.submissions))
+	for _, id := range state.game.players {
+		if _, ok := state.submissions[id]; ok {
+			submitted = append(submitted, id)
+		}
+	}
+	return submitted
+}
+
+// Checks if every player still in the room has handed in this step,
+// not counting the player who is leaving if there is one
+func (state *TelephoneState) allSubmitted(room *room, leaving *player) bool {
+	for _, id := range state.game.players {
+		p := room.Players[id]
+		if p == nil || p == leaving {
+			continue
+		}
+		if _, ok := state.submissions[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Hands in a player's entry for this step. Text steps send the text,
+// drawing steps hand in whatever is on the player's canvas.
+func (state *TelephoneState) handleSubmit(room *room, cmd *Command) error {
+	if state.game.indexOf(cmd.Player.ID) < 0 {
+		return ErrNotInTelephoneGame
+	}
+	if _, ok := state.submissions[cmd.Player.ID]; ok {
+		return ErrAlreadySubmitted
+	}
+
+	entry := TelephoneEntry{PlayerID: cmd.Player.ID, Kind: state.kind()}
+	if state.kind() == TelephoneStepDrawing {
+		if canvas := state.canvases[cmd.Player.ID]; canvas != nil {
+			entry.Strokes = canvas.strokes
+		}
+	} else {
+		text, _ := cmd.Payload.(string)
+		entry.Text = sanitizeTelephoneText(text)
+		if entry.Text == "" {
+			return ErrInvalidTelephoneEntry
+		}
+	}
+	state.submissions[cmd.Player.ID] = entry
+
+	room.broadcast(GameRoleAny,
+		event(SetTelephoneSubmittedEvt, state.submitted()),
+	)
+
+	// No need to wait for the timer once everyone is done
+	if state.allSubmitted(room, nil) {
+		room.Transition()
+	}
+	return nil
+}
+
+// Applies a canvas command to the sender's own canvas. Unlike classic rounds
+// nothing is sent to the other players, each drawing stays hidden until the reveal.
+func (state *TelephoneState) handleCanvasCommand(room *room, cmd *Command) error {
+	canvas := state.canvases[cmd.Player.ID]
+	if canvas == nil {
+		return ErrWrongGameRole
+	}
+	if _, ok := state.submissions[cmd.Player.ID]; ok {
+		return ErrAlreadySubmitted
+	}
+	if time.Now().After(state.endsAt) {
+		return nil // Silently ignore strokes after the step ends
+	}
+
+	switch cmd.Type {
+	case AddStrokeCmd:
+		stroke, err := decodeStroke(cmd.Payload)
+		if err != nil {
+			return commandErrorf(ErrCodeInvalidStroke, "failed to decode stroke: %v", err)
+		}
+		return canvas.addStroke(stroke)
+	case AddStrokePointCmd:
+		point, err := decodeStrokePoint(cmd.Payload)
+		if err != nil {
+			return commandErrorf(ErrCodeInvalidStroke, "failed to decode stroke: %v", err)
+		}
+		return canvas.addStrokePoints(point)
+	case AddStrokePointsCmd:
+		points, err := decodeStrokePoints(cmd.Payload)
+		if err != nil {
+			return commandErrorf(ErrCodeInvalidStroke, "failed to decode stroke: %v", err)
+		}
+		return canvas.addStrokePoints(points...)
+	case ClearStrokesCmd:
+		canvas.strokes = make([]Stroke, 0)
+	case UndoStrokeCmd:
+		canvas.strokes = removeLastStroke(canvas.strokes)
+	}
+	return nil
+}
+
+// Catches up a player who joined or came back. Players who were in the game
+// pick up their task where they left it, anyone else waits for the reveal.
+func (state *TelephoneState) handlePlayerJoined(room *room, cmd *Command) error {
+	cmd.Player.Send(
+		event(SetCurrentStateEvt, Telephone),
+		event(SetTimerEvt, state.endsAt.UTC()),
+		event(SetTelephoneSubmittedEvt, state.submitted()),
+	)
+	if i := state.game.indexOf(cmd.Player.ID); i >= 0 {
+		state.sendTask(room, cmd.Player, i)
+	} else {
+		cmd.Player.Send(event(SetTelephoneTaskEvt, nil))
+	}
+	return nil
+}
+
+// Moves on early if everyone who is left has already handed in this step
+func (state *TelephoneState) handlePlayerLeft(room *room, cmd *Command) error {
+	if state.game.indexOf(cmd.Player.ID) < 0 {
+		return nil
+	}
+	if state.allSubmitted(room, cmd.Player) {
+		// The leaving player is still in the room, so move on once they're gone
+		room.scheduler.addEvent(ScheduledStateChange, time.Now(), func() {
+			room.Transition()
+		})
+	}
+	return nil
+}
+
+// TelephoneRevealState walks everyone through the finished chains one entry at a time
+type TelephoneRevealState struct {
+	game     *telephoneGame
+	position TelephoneRevealPosition
+}
+
+func NewTelephoneRevealState(game *telephoneGame) RoomState {
+	return &TelephoneRevealState{game: game}
+}
+
+func (state *TelephoneRevealState) Enter(room *room) {
+	room.broadcast(GameRoleAny,
+		event(SetTelephoneChainsEvt, state.game.chains),
+		event(SetCurrentStateEvt, TelephoneReveal),
+		event(SetPlayersEvt, room.Players),
+	)
+	state.show(room)
+
+	room.scheduler.addReccuringEvent(ScheduledTelephoneReveal, room.config().Game.TelephoneRevealInterval.Duration, 0, func() {
+		if !state.next() {
+			room.Transition()
+			return
+		}
+		state.show(room)
+	})
+}
+
+func (state *TelephoneRevealState) Exit(room *room) {
+	room.scheduler.cancelEvent(ScheduledTelephoneReveal)
+	room.setState(NewWaitingState())
+}
+
+func (state *TelephoneRevealState) HandleCommand(room *room, cmd *Command) error {
+	switch cmd.Type {
+	case PlayerJoinedCmd:
+		cmd.Player.Send(
+			event(SetTelephoneChainsEvt, state.game.chains),
+			event(SetCurrentStateEvt, TelephoneReveal),
+			event(SetTelephoneRevealEvt, state.position),
+			event(SetStrokesEvt, state.strokes()),
+		)
+		return nil
+	case PlayerLeftCmd:
+		return nil
+	default:
+		slog.Error("Invalid command for current state", "command", cmd.Type)
+		return ErrInvalidCommand
+	}
+}
+
+// Moves on to the next entry, returns false once every chain has been shown
+func (state *TelephoneRevealState) next() bool {
+	state.position.Entry++
+	if state.position.Entry < len(state.game.chains[state.position.Chain].Entries) {
+		return true
+	}
+	state.position.Entry = 0
+	state.position.Chain++
+	return state.position.Chain < len(state.game.chains)
+}
+
+// Returns the drawing of the entry being shown, if it is one
+func (state *TelephoneRevealState) strokes() []Stroke {
+	entries := state.game.chains[state.position.Chain].Entries
+	if state.position.Entry >= len(entries) || entries[state.position.Entry].Strokes == nil {
+		return make([]Stroke, 0)
+	}
+	return entries[state.position.Entry].Strokes
+}
+
+// Shows everyone the current entry
+func (state *TelephoneRevealState) show(room *room) {
+	room.broadcast(GameRoleAny,
+		event(SetTelephoneRevealEvt, state.position),
+		event(SetStrokesEvt, state.strokes()),
+	)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTelephoneGame_ChainFor(t *testing.T) {
+	room, _ := newTestRoom(GameModeTelephone, 4)
+	game := newTelephoneGame(room)
+
+	seen := make(map[*TelephoneChain]map[int]bool)
+	for step := 0; step < game.steps(); step++ {
+		handed := make(map[*TelephoneChain]bool)
+		for i := range game.players {
+			chain := game.chainFor(i, step)
+			if handed[chain] {
+				t.Fatalf("expected every chain to be handed to one player in step %d", step)
+			}
+			handed[chain] = true
+
+			if seen[chain] == nil {
+				seen[chain] = make(map[int]bool)
+			}
+			if seen[chain][i] {
+				t.Fatalf("expected player %d to get each chain once", i)
+			}
+			seen[chain][i] = true
+		}
+	}
+
+	for i := range game.players {
+		if game.chainFor(i, 0).OwnerID != game.players[i] {
+			t.Errorf("expected player %d to start their own chain", i)
+		}
+	}
+}
+
+func TestTelephoneStepKind(t *testing.T) {
+	tests := []struct {
+		step int
+		want TelephoneStepKind
+	}{
+		{0, TelephoneStepPrompt},
+		{1, TelephoneStepDrawing},
+		{2, TelephoneStepDescription},
+		{3, TelephoneStepDrawing},
+		{4, TelephoneStepDescription},
+	}
+	for _, tt := range tests {
+		if got := telephoneStepKind(tt.step); got != tt.want {
+			t.Errorf("step %d: expected %s, got %s", tt.step, tt.want, got)
+		}
+	}
+}
+
+func TestTelephoneState_PlaysThroughChains(t *testing.T) {
+	room, players := newTestRoom(GameModeTelephone, 3)
+	room.Transition()
+
+	submit := func(p *player, payload interface{}) {
+		t.Helper()
+		if err := room.currentState.HandleCommand(room, &Command{Type: TelephoneSubmitCmd, Player: p, Payload: payload}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Everyone writes a prompt, the step moves on once the last one is in
+	for _, p := range players {
+		submit(p, "a cat")
+	}
+	state, ok := room.currentState.(*TelephoneState)
+	if !ok || state.step != 1 {
+		t.Fatalf("expected the drawing step, got %T", room.currentState)
+	}
+	for _, p := range players {
+		if p.GameRole != GameRoleDrawing {
+			t.Fatal("expected everyone to draw at the same time")
+		}
+	}
+
+	// Strokes only go on the sender's own canvas
+	stroke := map[string]interface{}{"points": [][]int{{10, 10}}, "color": "#000000", "width": 5, "type": StrokeTypeBrush}
+	if err := state.HandleCommand(room, &Command{Type: AddStrokeCmd, Player: players[0], Payload: stroke}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(state.canvases[players[0].ID].strokes) != 1 || len(state.canvases[players[1].ID].strokes) != 0 {
+		t.Fatal("expected the stroke to only be added to the sender's canvas")
+	}
+	for _, p := range players {
+		submit(p, nil)
+	}
+
+	// The last step describes the drawings, then the reveal starts
+	for _, p := range players {
+		submit(p, "a dog")
+	}
+	if _, ok := room.currentState.(*TelephoneRevealState); !ok {
+		t.Fatalf("expected the reveal, got %T", room.currentState)
+	}
+	for _, p := range players {
+		if p.GameRole != GameRoleGuessing {
+			t.Error("expected the drawing roles to be reset")
+		}
+	}
+
+	// The first player's prompt was drawn by the second and described by the third
+	chain := state.game.chains[0]
+	if len(chain.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(chain.Entries))
+	}
+	for i, entry := range chain.Entries {
+		if entry.PlayerID != players[i].ID {
+			t.Errorf("expected entry %d to be from player %d", i, i)
+		}
+		if entry.Kind != telephoneStepKind(i) {
+			t.Errorf("expected entry %d to be a %s, got %s", i, telephoneStepKind(i), entry.Kind)
+		}
+	}
+	if len(state.game.chains[2].Entries[1].Strokes) != 1 {
+		t.Error("expected the first player's drawing to be in the last chain")
+	}
+}
+
+func TestTelephoneState_HandleCommand_Errors(t *testing.T) {
+	room, players := newTestRoom(GameModeTelephone, 3)
+	room.Transition()
+	outsider := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, client: NewClient(nil, nil, nil)}
+	room.Players[outsider.ID] = outsider
+
+	if err := room.currentState.HandleCommand(room, &Command{Type: TelephoneSubmitCmd, Player: players[0], Payload: "a cat"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cmd     *Command
+		wantErr error
+	}{
+		{name: "already submitted", cmd: &Command{Type: TelephoneSubmitCmd, Player: players[0], Payload: "a dog"}, wantErr: ErrAlreadySubmitted},
+		{name: "not in the game", cmd: &Command{Type: TelephoneSubmitCmd, Player: outsider, Payload: "a dog"}, wantErr: ErrNotInTelephoneGame},
+		{name: "empty prompt", cmd: &Command{Type: TelephoneSubmitCmd, Player: players[1], Payload: "   "}, wantErr: ErrInvalidTelephoneEntry},
+		{name: "not a string", cmd: &Command{Type: TelephoneSubmitCmd, Player: players[1], Payload: 5}, wantErr: ErrInvalidTelephoneEntry},
+		{name: "drawing in a writing step", cmd: &Command{Type: ClearStrokesCmd, Player: players[1]}, wantErr: ErrWrongGameRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := room.currentState.HandleCommand(room, tt.cmd)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTelephoneState_TimesOut(t *testing.T) {
+	room, players := newTestRoom(GameModeTelephone, 2)
+	room.Transition()
+	state := room.currentState.(*TelephoneState)
+
+	if err := state.HandleCommand(room, &Command{Type: TelephoneSubmitCmd, Player: players[0], Payload: "a cat"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The timer runs out before the second player writes anything
+	room.Transition()
+	if next, ok := room.currentState.(*TelephoneState); !ok || next.step != 1 {
+		t.Fatalf("expected the drawing step, got %T", room.currentState)
+	}
+	chain := state.game.chains[1]
+	if len(chain.Entries) != 1 || chain.Entries[0].PlayerID != players[1].ID || chain.Entries[0].Text != "" {
+		t.Errorf("expected a blank entry for the missing prompt, got %+v", chain.Entries)
+	}
+}
+
+func TestTelephoneState_PlayerLeft(t *testing.T) {
+	room, players := newTestRoom(GameModeTelephone, 3)
+	room.Transition()
+	state := room.currentState.(*TelephoneState)
+
+	for _, p := range players[:2] {
+		if err := state.HandleCommand(room, &Command{Type: TelephoneSubmitCmd, Player: p, Payload: "a cat"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Everyone who is left is done, so the step ends right away
+	state.HandleCommand(room, &Command{Type: PlayerLeftCmd, Player: players[2]})
+	if !room.scheduler.events[ScheduledStateChange].nextRunAt.Before(state.endsAt) {
+		t.Error("expected the step to end early")
+	}
+}
+
+func TestTelephoneRevealState_WalksThroughChains(t *testing.T) {
+	room, _ := newTestRoom(GameModeTelephone, 3)
+	game := newTelephoneGame(room)
+	for _, chain := range game.chains {
+		for range game.players {
+			chain.Entries = append(chain.Entries, TelephoneEntry{})
+		}
+	}
+
+	state := NewTelephoneRevealState(game).(*TelephoneRevealState)
+	shown := 1
+	for state.next() {
+		shown++
+	}
+	if shown != 9 {
+		t.Errorf("expected all 9 entries to be shown, got %d", shown)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Players can vote to remove someone who is ruining the game when the host
+// can't or won't, like a griefing host or a drawer who walked away.
+//
+// Any player can open a vote against another player. It stays open for
+// Game.VoteKickDuration, and the target is removed as soon as more than half
+// of the other players vote yes. Removed players can't come back until
+// Game.VoteKickRejoinCooldown has passed.
+
+const (
+	ScheduledVoteKickEnd ScheduledEventType = "vote_kick_end"
+
+	// Below this many players a vote kick would just be one player kicking another
+	VOTE_KICK_MIN_PLAYERS = 3
+)
+
+// Close reason sent to players removed by a vote
+var ErrVoteKicked = errors.New("ErrVoteKicked")
+
+var (
+	ErrVoteKickInProgress     = &CommandError{ErrCodeVoteKickInProgress, "a vote kick is already in progress"}
+	ErrVoteKickCooldown       = &CommandError{ErrCodeVoteKickCooldown, "wait a bit before starting another vote kick"}
+	ErrVoteKickTargetRecent   = &CommandError{ErrCodeVoteKickCooldown, "that player was voted on recently"}
+	ErrVoteKickNotEnough      = &CommandError{ErrCodeNotEnoughPlayers, "you need at least 3 players to start a vote kick"}
+	ErrNoVoteKick             = &CommandError{ErrCodeWrongPhase, "there is no vote kick in progress"}
+	ErrVoteKickSelf           = &CommandError{ErrCodeInvalidPayload, "you can't vote to kick yourself"}
+	ErrVoteKickTargetCantVote = &CommandError{ErrCodeWrongRoomRole, "the player being voted on can't vote"}
+)
+
+// An open vote to remove a player from the room
+type voteKick struct {
+	target    *player
+	startedBy uuid.UUID
+	votes     map[uuid.UUID]bool
+	endsAt    time.Time
+}
+
+// VoteKickStatus is sent to players so they can see how the vote is going
+type VoteKickStatus struct {
+	TargetID  uuid.UUID   `json:"targetId"`
+	StartedBy uuid.UUID   `json:"startedBy"`
+	Votes     []uuid.UUID `json:"votes"`
+	Needed    int         `json:"needed"`
+	EndsAt    time.Time   `json:"endsAt"`
+}
+
+// Returns how many yes votes are needed to remove the target,
+// more than half of the players other than the target
+func votesNeeded(players int) int {
+	return (players-1)/2 + 1
+}
+
+// Opens a vote to remove the player in the payload, the player
+// who started it counts as the first yes vote
+func (r *room) handleStartVoteKick(cmd *Command) error {
+	starter := cmd.Player
+	if starter.isSpectator() {
+		return ErrWrongRoomRole
+	}
+	if r.voteKick != nil {
+		return ErrVoteKickInProgress
+	}
+	if r.playerCount() < VOTE_KICK_MIN_PLAYERS {
+		return ErrVoteKickNotEnough
+	}
+
+	id, ok := cmd.Payload.(string)
+	if !ok {
+		return ErrInvalidPayload
+	}
+	targetID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	if targetID == starter.ID {
+		return ErrVoteKickSelf
+	}
+	target, ok := r.Players[targetID]
+	if !ok || target.isSpectator() {
+		return ErrPlayerNotFound
+	}
+
+	// Keep players from spamming votes, either by starting them
+	// one after another or by going after the same player
+	now := time.Now()
+	cooldown := r.config().Game.VoteKickCooldown.Duration
+	if at, ok := r.voteKickStartedAt[starter.ID]; ok && now.Sub(at) < cooldown {
+		return ErrVoteKickCooldown
+	}
+	if at, ok := r.voteKickTargetedAt[target.ID]; ok && now.Sub(at) < cooldown {
+		return ErrVoteKickTargetRecent
+	}
+	if r.voteKickStartedAt == nil {
+		r.voteKickStartedAt = make(map[uuid.UUID]time.Time)
+	}
+	if r.voteKickTargetedAt == nil {
+		r.voteKickTargetedAt = make(map[uuid.UUID]time.Time)
+	}
+	r.voteKickStartedAt[starter.ID] = now
+	r.voteKickTargetedAt[target.ID] = now
+
+	r.voteKick = &voteKick{
+		target:    target,
+		startedBy: starter.ID,
+		votes:     map[uuid.UUID]bool{starter.ID: true},
+		endsAt:    now.Add(r.config().Game.VoteKickDuration.Duration),
+	}
+
+	// Votes aren't tied to the game, so they stay open across state changes
+	r.scheduler.addRoomEvent(ScheduledVoteKickEnd, r.voteKick.endsAt, func() {
+		r.endVoteKick()
+	})
+
+	slog.Info("vote kick started", "roomId", r.ID, "targetId", target.ID, "startedBy", starter.ID)
+	r.SendSystemMessage(fmt.Sprintf("%s started a vote to kick %s", starter.Username, target.Username))
+	r.broadcastVoteKick()
+	r.checkVoteKick()
+	return nil
+}
+
+// Records a player's vote, the payload is true to vote yes and false to take it back
+func (r *room) handleVoteKick(cmd *Command) error {
+	if r.voteKick == nil {
+		return ErrNoVoteKick
+	}
+	if cmd.Player.isSpectator() {
+		return ErrWrongRoomRole
+	}
+	if cmd.Player == r.voteKick.target {
+		return ErrVoteKickTargetCantVote
+	}
+
+	yes, ok := cmd.Payload.(bool)
+	if !ok {
+		return ErrInvalidPayload
+	}
+	if yes {
+		r.voteKick.votes[cmd.Player.ID] = true
+	} else {
+		delete(r.voteKick.votes, cmd.Player.ID)
+	}
+
+	r.broadcastVoteKick()
+	r.checkVoteKick()
+	return nil
+}
+
+// Removes the target once enough players voted yes
+func (r *room) checkVoteKick() {
+	vote := r.voteKick
+	if vote == nil || len(vote.votes) < votesNeeded(r.playerCount()) {
+		return
+	}
+
+	r.closeVoteKick()
+	slog.Info("vote kick passed", "roomId", r.ID, "targetId", vote.target.ID)
+
+	// The target can't rejoin right away
+	r.ban(vote.target, ErrVoteKicked, time.Now().Add(r.config().Game.VoteKickRejoinCooldown.Duration))
+	r.removePlayer(vote.target, ErrVoteKicked)
+	r.SendSystemMessage(fmt.Sprintf("%s was kicked by vote", vote.target.Username))
+}
+
+// Ends the vote when time runs out, the target stays if there weren't enough votes
+func (r *room) endVoteKick() {
+	vote := r.voteKick
+	if vote == nil {
+		return
+	}
+
+	r.checkVoteKick()
+	if r.voteKick == nil {
+		return
+	}
+
+	r.closeVoteKick()
+	slog.Info("vote kick failed", "roomId", r.ID, "targetId", vote.target.ID)
+	r.SendSystemMessage(fmt.Sprintf("Not enough votes to kick %s", vote.target.Username))
+}
+
+// Updates an open vote when a player leaves. The vote is dropped if the
+// target left or there aren't enough players left for a fair vote,
+// otherwise the player's vote is taken back.
+func (r *room) handleVoteKickPlayerLeft(p *player) {
+	vote := r.voteKick
+	if vote == nil {
+		return
+	}
+
+	if p == vote.target {
+		r.closeVoteKick()
+		return
+	}
+	if !p.isSpectator() && r.playerCount()-1 < VOTE_KICK_MIN_PLAYERS {
+		r.closeVoteKick()
+		r.SendSystemMessage(fmt.Sprintf("Vote to kick %s cancelled, not enough players left", vote.target.Username))
+		return
+	}
+	if vote.votes[p.ID] {
+		delete(vote.votes, p.ID)
+		r.broadcastVoteKick()
+	}
+}
+
+// Closes the open vote and tells players it's over
+func (r *room) closeVoteKick() {
+	r.voteKick = nil
+	r.scheduler.cancelEvent(ScheduledVoteKickEnd)
+	r.broadcastVoteKick()
+}
+
+func (r *room) broadcastVoteKick() {
+	r.broadcast(GameRoleAny,
+		event(SetVoteKickEvt, r.voteKickStatus()),
+	)
+}
+
+// Returns the status of the open vote, or nil if there isn't one
+func (r *room) voteKickStatus() *VoteKickStatus {
+	vote := r.voteKick
+	if vote == nil {
+		return nil
+	}
+
+	votes := make([]uuid.UUID, 0, len(vote.votes))
+	for id := range vote.votes {
+		votes = append(votes, id)
+	}
+	return &VoteKickStatus{
+		TargetID:  vote.target.ID,
+		StartedBy: vote.startedBy,
+		Votes:     votes,
+		Needed:    votesNeeded(r.playerCount()),
+		EndsAt:    vote.endsAt.UTC(),
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVotesNeeded(t *testing.T) {
+	tests := []struct {
+		players int
+		want    int
+	}{
+		{players: 3, want: 2},
+		{players: 4, want: 2},
+		{players: 5, want: 3},
+		{players: 10, want: 5},
+	}
+
+	for _, tt := range tests {
+		if got := votesNeeded(tt.players); got != tt.want {
+			t.Errorf("votesNeeded(%d) = %d, want %d", tt.players, got, tt.want)
+		}
+	}
+}
+
+func TestVoteKick_RemovesTargetOnMajority(t *testing.T) {
+	room, players := newTestRoom(GameModeClassic, 5)
+	host, a, b, c := players[0], players[1], players[2], players[3]
+
+	// Players can vote out an abusive host
+	if err := room.handleStartVoteKick(&Command{Type: StartVoteKickCmd, Player: a, Payload: host.ID.String()}); err != nil {
+		t.Fatalf("unexpected error starting the vote: %v", err)
+	}
+	if err := room.handleVoteKick(&Command{Type: VoteKickCmd, Player: host, Payload: false}); !errors.Is(err, ErrVoteKickTargetCantVote) {
+		t.Errorf("expected the target not to be able to vote, got %v", err)
+	}
+
+	// A vote taken back doesn't count
+	room.handleVoteKick(&Command{Type: VoteKickCmd, Player: b, Payload: true})
+	room.handleVoteKick(&Command{Type: VoteKickCmd, Player: b, Payload: false})
+	if _, ok := room.Players[host.ID]; !ok {
+		t.Fatal("expected the target to stay until enough players vote")
+	}
+
+	// 3 out of the 4 other players is a majority
+	room.handleVoteKick(&Command{Type: VoteKickCmd, Player: b, Payload: true})
+	room.handleVoteKick(&Command{Type: VoteKickCmd, Player: c, Payload: true})
+	if _, ok := room.Players[host.ID]; ok {
+		t.Fatal("expected the target to be removed once a majority voted")
+	}
+	if room.voteKick != nil || room.scheduler.events[ScheduledVoteKickEnd] != nil {
+		t.Error("expected the vote to be closed")
+	}
+	if next := room.nextHost(uuid.Nil); next == nil || next.RoomRole != RoomRoleHost {
+		t.Error("expected the host role to move to another player")
+	}
+
+	// They can't come back until the cooldown is over
+	err := room.register(context.Background(), &player{ID: uuid.New(), RoomRole: RoomRolePlayer, remoteIP: host.remoteIP})
+	if !errors.Is(err, ErrVoteKicked) {
+		t.Errorf("expected rejoining to fail with %v, got %v", ErrVoteKicked, err)
+	}
+	room.bannedPlayers[host.ID] = roomBan{cause: ErrVoteKicked, until: time.Now().Add(-time.Second)}
+	room.bannedIPs[host.remoteIP] = roomBan{cause: ErrVoteKicked, until: time.Now().Add(-time.Second)}
+	if err := room.checkBanned(host.ID, host.remoteIP); err != nil {
+		t.Errorf("expected the target to be allowed back after the cooldown, got %v", err)
+	}
+}
+
+func TestVoteKick_FailsWithoutMajority(t *testing.T) {
+	room, players := newTestRoom(GameModeClassic, 4)
+	a, target := players[1], players[2]
+
+	room.handleStartVoteKick(&Command{Type: StartVoteKickCmd, Player: a, Payload: target.ID.String()})
+
+	// Time runs out with only the starter's vote
+	room.endVoteKick()
+	if _, ok := room.Players[target.ID]; !ok {
+		t.Error("expected the target to stay when the vote fails")
+	}
+	if room.voteKick != nil {
+		t.Error("expected the vote to be closed")
+	}
+}
+
+func TestVoteKick_SpamProtection(t *testing.T) {
+	room, players := newTestRoom(GameModeClassic, 4)
+	host, a, b, c := players[0], players[1], players[2], players[3]
+
+	start := func(p, target *player) error {
+		return room.handleStartVoteKick(&Command{Type: StartVoteKickCmd, Player: p, Payload: target.ID.String()})
+	}
+
+	if err := start(a, b); err != nil {
+		t.Fatalf("unexpected error starting the vote: %v", err)
+	}
+	if err := start(c, host); !errors.Is(err, ErrVoteKickInProgress) {
+		t.Errorf("expected only one vote at a time, got %v", err)
+	}
+	room.endVoteKick()
+
+	if err := start(a, c); !errors.Is(err, ErrVoteKickCooldown) {
+		t.Errorf("expected the starter to have to wait before starting another vote, got %v", err)
+	}
+	if err := start(c, b); !errors.Is(err, ErrVoteKickTargetRecent) {
+		t.Errorf("expected the same player not to be voted on again right away, got %v", err)
+	}
+	if err := start(c, c); !errors.Is(err, ErrVoteKickSelf) {
+		t.Errorf("expected players not to be able to vote on themselves, got %v", err)
+	}
+
+	// Small rooms can't vote kick at all
+	room.unregister(host)
+	room.unregister(b)
+	if err := start(c, a); !errors.Is(err, ErrVoteKickNotEnough) {
+		t.Errorf("expected too few players to vote kick, got %v", err)
+	}
+}
+
+func TestVoteKick_CancelledWhenTargetLeaves(t *testing.T) {
+	room, players := newTestRoom(GameModeClassic, 4)
+	a, target := players[1], players[2]
+
+	room.handleStartVoteKick(&Command{Type: StartVoteKickCmd, Player: a, Payload: target.ID.String()})
+	room.unregister(target)
+
+	if room.voteKick != nil || room.scheduler.events[ScheduledVoteKickEnd] != nil {
+		t.Error("expected the vote to be cancelled when the target leaves")
+	}
+}
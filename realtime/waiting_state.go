@@ -3,6 +3,13 @@ package main
 import (
 	"fmt"
 	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotSpectator = &CommandError{ErrCodeNotSpectator, "player is not a spectator"}
+	ErrNoFreeSeats  = &CommandError{ErrCodeRoomFull, "the room is full"}
 )
 
 // WaitingState handles the pre-game lobby where players wait to start
@@ -15,14 +22,25 @@ func NewWaitingState() RoomState {
 
 // Enter broadcasts the current state and players to all clients
 func (state *WaitingState) Enter(room *room) {
+	// Everyone is back in the lobby, so nobody is sitting out anymore
+	for _, p := range room.Players {
+		p.SittingOut = false
+	}
+
 	room.broadcast(GameRoleAny,
 		event(SetCurrentStateEvt, Waiting),
 		event(SetPlayersEvt, room.Players),
 	)
 }
 
-// Exit moves the room to picking state with random word options
+// Exit moves the room to picking state with random word options,
+// or straight to writing prompts in telephone games
 func (state *WaitingState) Exit(room *room) {
+	if room.Settings.GameMode == GameModeTelephone {
+		room.setState(NewTelephoneState(newTelephoneGame(room), 0))
+		return
+	}
+
 	room.setState(
 		NewPickingState(
 			randomWordOptions(3,
@@ -44,6 +62,10 @@ func (state *WaitingState) HandleCommand(room *room, cmd *Command) error {
 		return state.handlePlayerJoined(room, cmd)
 	case ChatMessageCmd:
 		return state.handleChatMessage(room, cmd)
+	case PromoteSpectatorCmd:
+		return state.handlePromoteSpectator(room, cmd)
+	case AssignTeamCmd:
+		return state.handleAssignTeam(room, cmd)
 	default:
 		slog.Error("Invalid command for current state", "command", cmd.Type)
 		return ErrInvalidCommand
@@ -52,19 +74,44 @@ func (state *WaitingState) HandleCommand(room *room, cmd *Command) error {
 
 // handleGameStart checks if game can start and initiates it
 func (state *WaitingState) handleGameStart(room *room, cmd *Command) error {
-	if len(room.Players) < 2 {
+	if err := canStartGame(room); err != nil {
+		return err
+	}
+	if cmd.Player.RoomRole != RoomRoleHost {
+		return ErrWrongRoomRole
+	}
+
+	state.startGame(room)
+	return nil
+}
+
+// Checks if the room has what it needs to start a game
+func canStartGame(room *room) error {
+	if room.activePlayerCount() < MIN_PLAYERS {
 		return ErrNotEnoughPlayers
 	}
 	if len(room.Settings.CustomWords) < 3 && room.Settings.WordBank == WordBankCustom {
 		return ErrNotEnoughCustomWords
 	}
-	if cmd.Player.RoomRole != RoomRoleHost {
-		return ErrWrongRoomRole
+	if room.isTeamGame() {
+		return room.checkTeams()
 	}
+	if room.isRelayGame() && room.activePlayerCount() < MIN_RELAY_PLAYERS {
+		return ErrNotEnoughRelayPlayers
+	}
+	return nil
+}
 
+// Starts a new game, the room must be able to start one, see canStartGame.
+// This is also how a rematch starts once enough players vote for it.
+func (state *WaitingState) startGame(room *room) {
 	room.resetGameState()
+	room.broadcast(GameRoleAny,
+		event(SetFinalStandingsEvt, nil),
+		event(SetTeamStandingsEvt, nil),
+		event(SetTelephoneTaskEvt, nil),
+	)
 	room.Transition()
-	return nil
 }
 
 // handleRoomSettingsChange updates room settings if valid
@@ -79,23 +126,50 @@ func (state *WaitingState) handleRoomSettingsChange(room *room, cmd *Command) er
 		return fmt.Errorf("failed to decode room settings: %w", err)
 	}
 
+	// Clients from before scoring modes don't send one
+	if settings.ScoringMode == "" {
+		settings.ScoringMode = ScoringModeClassic
+	}
+
 	// Validate the settings before applying them
-	if err := validateRoomSettings(&settings); err != nil {
+	if err := validateRoomSettings(&settings, room.config().Rooms); err != nil {
 		slog.Error("invalid room settings", "error", err)
 		return fmt.Errorf("invalid room settings: %w", err)
 	}
 
+	// Only the hash of the password is kept
+	if settings.Password != nil {
+		room.password.set(*settings.Password)
+		settings.Password = nil
+	}
+	settings.HasPassword = room.password.isSet()
+
 	room.Settings = settings
 
 	// Inform clients of the room settings change
 	room.broadcast(GameRoleAny,
 		event(ChangeRoomSettingsEvt, room.Settings),
 	)
+
+	// Players are put on teams when switching to team mode
+	if room.syncTeams() {
+		room.broadcast(GameRoleAny,
+			event(SetPlayersEvt, room.Players),
+		)
+	}
 	return nil
 }
 
 // handlePlayerJoined sends current state to new players
 func (state *WaitingState) handlePlayerJoined(room *room, cmd *Command) error {
+	// Show the results of the last game if there was one
+	if room.finalStandings != nil {
+		cmd.Player.Send(event(SetFinalStandingsEvt, room.finalStandings))
+	}
+	if room.teamStandings != nil {
+		cmd.Player.Send(event(SetTeamStandingsEvt, room.teamStandings))
+	}
+
 	cmd.Player.Send(
 		event(SetCurrentStateEvt, Waiting),
 	)
@@ -116,3 +190,39 @@ func (state *WaitingState) handleChatMessage(room *room, cmd *Command) error {
 	)
 	return nil
 }
+
+// handlePromoteSpectator lets the host give a spectator a seat in the room.
+// This only happens between games so the drawing queue isn't disrupted.
+func (state *WaitingState) handlePromoteSpectator(room *room, cmd *Command) error {
+	if cmd.Player.RoomRole != RoomRoleHost {
+		return ErrWrongRoomRole
+	}
+
+	id, ok := cmd.Payload.(string)
+	if !ok {
+		return ErrInvalidPayload
+	}
+	playerID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	spectator, ok := room.Players[playerID]
+	if !ok || !spectator.isSpectator() {
+		return ErrNotSpectator
+	}
+	if room.playerCount() >= room.Settings.PlayerLimit {
+		return ErrNoFreeSeats
+	}
+
+	spectator.RoomRole = RoomRolePlayer
+	if room.isTeamGame() {
+		spectator.Team = room.smallestTeam()
+	}
+
+	room.broadcast(GameRoleAny,
+		event(SetPlayersEvt, room.Players),
+	)
+	room.SendSystemMessage(fmt.Sprintf("%s joined the game", spectator.Username))
+	return nil
+}
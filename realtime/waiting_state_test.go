@@ -359,3 +359,76 @@ func TestWaitingState_HandleCommand_ChangeRoomSettings_RolePermissions(t *testin
 		})
 	}
 }
+
+func TestWaitingState_HandleCommand_PromoteSpectator(t *testing.T) {
+	tests := []struct {
+		name          string
+		playerRole    RoomRole
+		target        RoomRole
+		playerLimit   int
+		expectedError error
+	}{
+		{
+			name:          "host can promote spectator",
+			playerRole:    RoomRoleHost,
+			target:        RoomRoleSpectator,
+			playerLimit:   6,
+			expectedError: nil,
+		},
+		{
+			name:          "player cannot promote spectator",
+			playerRole:    RoomRolePlayer,
+			target:        RoomRoleSpectator,
+			playerLimit:   6,
+			expectedError: ErrWrongRoomRole,
+		},
+		{
+			name:          "cannot promote a player",
+			playerRole:    RoomRoleHost,
+			target:        RoomRolePlayer,
+			playerLimit:   6,
+			expectedError: ErrNotSpectator,
+		},
+		{
+			name:          "cannot promote into a full room",
+			playerRole:    RoomRoleHost,
+			target:        RoomRoleSpectator,
+			playerLimit:   2,
+			expectedError: ErrNoFreeSeats,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			host := &player{ID: uuid.New(), RoomRole: tt.playerRole, client: NewClient(nil, nil, nil)}
+			other := &player{ID: uuid.New(), RoomRole: RoomRolePlayer, client: NewClient(nil, nil, nil)}
+			target := &player{ID: uuid.New(), RoomRole: tt.target, client: NewClient(nil, nil, nil)}
+			room := &room{
+				Players: map[uuid.UUID]*player{
+					host.ID:   host,
+					other.ID:  other,
+					target.ID: target,
+				},
+				Settings:     RoomSettings{PlayerLimit: tt.playerLimit},
+				ChatMessages: make([]ChatMessage, 0),
+				currentState: NewWaitingState(),
+			}
+
+			// Execute
+			err := room.currentState.HandleCommand(room, &Command{
+				Type:    PromoteSpectatorCmd,
+				Player:  host,
+				Payload: target.ID.String(),
+			})
+
+			// Assert
+			if err != tt.expectedError {
+				t.Errorf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if tt.expectedError == nil && target.RoomRole != RoomRolePlayer {
+				t.Errorf("expected spectator to be promoted, got %v", target.RoomRole)
+			}
+		})
+	}
+}
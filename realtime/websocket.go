@@ -3,37 +3,77 @@ package main
 import (
 	"log/slog"
 	"net/http"
-	"os"
 	"regexp"
+	"slices"
 
 	"github.com/gorilla/websocket"
 )
 
-// WebSocket upgrader config
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		origin := r.Header.Get("Origin")
+// Subprotocols clients can request when connecting.
+// Clients that don't request one get the JSON protocol.
+const (
+	JSONSubprotocol   = "sketch.json"
+	BinarySubprotocol = "sketch.binary.v1"
+)
 
+// Decides which frontends are allowed to talk to the server
+type originChecker struct {
+	allowAll bool
+	origins  []string
+	patterns []*regexp.Regexp
+}
+
+// The patterns were already checked when the config was validated
+func newOriginChecker(cfg ServerConfig) *originChecker {
+	o := &originChecker{
 		// Allow local development
-		if os.Getenv("ENVIRONMENT") != "PRODUCTION" {
-			return true
-		}
+		allowAll: cfg.Environment != "PRODUCTION",
+		origins:  cfg.AllowedOrigins,
+	}
+	for _, pattern := range cfg.AllowedOriginPatterns {
+		o.patterns = append(o.patterns, regexp.MustCompile(pattern))
+	}
+	return o
+}
 
-		// Allow production
-		if origin == "https://sketchwithfriends.com" {
+// Checks if the frontend at the origin is allowed to talk to the server
+func (o *originChecker) allowed(origin string) bool {
+	if o.allowAll {
+		return true
+	}
+
+	// Allow production
+	if slices.Contains(o.origins, origin) {
+		return true
+	}
+
+	// Allow preview environments
+	for _, pattern := range o.patterns {
+		if pattern.MatchString(origin) {
 			return true
 		}
+	}
+	return false
+}
 
-		// Allow preview environments
-		match, _ := regexp.MatchString(`^https?:\/\/([\w-]+\.)*sketch-with-friends\.pages\.dev$`, origin)
-		return match
-	},
+// WebSocket upgrader config
+func newUpgrader(origins *originChecker) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+
+		// Listed in order of preference, the first one the client also supports is used
+		Subprotocols: []string{BinarySubprotocol, JSONSubprotocol},
+
+		CheckOrigin: func(r *http.Request) bool {
+			return origins.allowed(r.Header.Get("Origin"))
+		},
+	}
 }
 
 // Upgrades an HTTP connection to a WebSocket connection.
-func UpgradeConnection(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
+// The subprotocol is negotiated here, see binary_protocol.go.
+func UpgradeConnection(upgrader *websocket.Upgrader, w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
 	return upgrader.Upgrade(w, r, nil)
 }
 